@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	pkgcatalog "dbx-explore/pkg/catalog"
+	"dbx-explore/pkg/identity"
+	"dbx-explore/pkg/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	accessPrincipal string
+	accessScopes    []string
+	accessOutput    string
+)
+
+var accessReportCmd = &cobra.Command{
+	Use:   "access-report",
+	Short: "List every securable and privilege a user, group or service principal can access",
+	Long: `Crawl effective permissions and report everything a principal can access,
+including privileges held through (nested) group membership and privileges
+inherited from parent catalogs and schemas.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+
+		dir := identity.NewDirectory(w)
+		principal, err := dir.Lookup(ctx, accessPrincipal)
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to resolve principal: %v", err))
+			os.Exit(1)
+		}
+		if principal == nil {
+			ui.PrintError(fmt.Sprintf("Principal %s not found in SCIM.", accessPrincipal))
+			os.Exit(1)
+		}
+
+		principals := map[string]bool{strings.ToLower(principal.Name): true}
+		for _, g := range principal.Groups {
+			principals[strings.ToLower(g)] = true
+		}
+		ui.PrintInfo(fmt.Sprintf("Resolved %s %s (member of %d groups)", principal.Kind, principal.Name, len(principal.Groups)))
+
+		scopes := accessScopes
+		if len(scopes) == 0 {
+			catalogs, err := pkgcatalog.ListCatalogs(ctx, w)
+			if err != nil {
+				ui.PrintError(fmt.Sprintf("Failed to list catalogs: %v", err))
+				os.Exit(1)
+			}
+			for _, c := range catalogs {
+				scopes = append(scopes, c.Name)
+			}
+		}
+
+		entries, err := pkgcatalog.CollectAccess(ctx, w, scopes, principals, func(s pkgcatalog.Securable) {
+			if s.Kind == pkgcatalog.KindCatalog || s.Kind == pkgcatalog.KindSchema {
+				ui.PrintInfo(fmt.Sprintf("Scanning %s...", s.FullName))
+			}
+		})
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		if accessOutput == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(entries); err != nil {
+				ui.PrintError(fmt.Sprintf("Failed to encode report: %v", err))
+				os.Exit(1)
+			}
+			return
+		}
+		printAccessReport(entries)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(accessReportCmd)
+	accessReportCmd.Flags().StringVar(&accessPrincipal, "principal", "", "User name, group name or service principal application ID")
+	accessReportCmd.Flags().StringSliceVar(&accessScopes, "scope", nil, "Limit the crawl to <catalog> or <catalog>.<schema>; repeatable (default: all catalogs)")
+	accessReportCmd.Flags().StringVarP(&accessOutput, "output", "o", "table", "Output format: table or json")
+	_ = accessReportCmd.MarkFlagRequired("principal")
+}
+
+// printAccessReport prints one table per inheritance source.
+func printAccessReport(entries []pkgcatalog.AccessEntry) {
+	if len(entries) == 0 {
+		ui.PrintInfo("No access found.")
+		return
+	}
+
+	bySource := make(map[string][][]string)
+	for _, e := range entries {
+		bySource[e.Source()] = append(bySource[e.Source()], []string{e.Securable.Type, e.Securable.FullName, e.Privilege, e.Via})
+	}
+
+	sources := make([]string, 0, len(bySource))
+	for s := range bySource {
+		sources = append(sources, s)
+	}
+	// Privileges granted on the object itself come first.
+	sort.Slice(sources, func(i, j int) bool {
+		if strings.HasPrefix(sources[i], "Granted") != strings.HasPrefix(sources[j], "Granted") {
+			return strings.HasPrefix(sources[i], "Granted")
+		}
+		return sources[i] < sources[j]
+	})
+
+	for _, s := range sources {
+		rows := bySource[s]
+		sort.Slice(rows, func(i, j int) bool { return strings.Join(rows[i], " ") < strings.Join(rows[j], " ") })
+		ui.PrintHeader(s)
+		ui.PrintTable([]string{"Type", "Securable", "Privilege", "Via"}, rows)
+	}
+	ui.PrintInfo(fmt.Sprintf("%d privileges across %d sources.", len(entries), len(sources)))
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"dbx-explore/pkg/audit"
+	pkgcatalog "dbx-explore/pkg/catalog"
+	"dbx-explore/pkg/identity"
+	"dbx-explore/pkg/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditRulesFile      string
+	auditOutput         string
+	auditFailOnFindings bool
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Compliance checks for Unity Catalog",
+}
+
+var auditPermissionsCmd = &cobra.Command{
+	Use:   "permissions <catalog>[.<schema>]",
+	Short: "Report over-privileged and orphaned grants",
+	Long: `Crawl direct grants and owners under a catalog or schema and report:
+
+  UC001  ALL PRIVILEGES granted to a broad group
+  UC002  grants to deleted principals or principals unknown to SCIM
+  UC003  objects owned by deactivated or unknown principals
+  UC004  MANAGE held outside the admin groups
+
+Broad groups, admin groups and disabled rules can be set in a YAML file
+passed with --rules (keys: broad_groups, admin_groups, disabled).`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+
+		cfg := audit.DefaultConfig()
+		if auditRulesFile != "" {
+			var err error
+			if cfg, err = audit.LoadConfig(auditRulesFile); err != nil {
+				ui.PrintError(err.Error())
+				os.Exit(1)
+			}
+		}
+
+		// Progress is only printed in table mode so JSON and SARIF output stay parseable.
+		quiet := auditOutput != "table"
+		dir := identity.NewDirectory(w)
+		auditor := audit.NewAuditor(cfg, dir.Lookup)
+
+		var findings []audit.Finding
+		err := pkgcatalog.WalkSecurables(ctx, w, args[0], func(s pkgcatalog.Securable) error {
+			if !quiet && (s.Kind == pkgcatalog.KindCatalog || s.Kind == pkgcatalog.KindSchema) {
+				ui.PrintInfo(fmt.Sprintf("Auditing %s...", s.FullName))
+			}
+			perms, err := pkgcatalog.GetPermissions(ctx, w, s.Type, s.FullName)
+			if err != nil {
+				return fmt.Errorf("failed to get permissions for %s: %w", s.FullName, err)
+			}
+			f, err := auditor.Evaluate(ctx, s, perms.PrivilegeAssignments)
+			if err != nil {
+				return err
+			}
+			findings = append(findings, f...)
+			return nil
+		})
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		switch auditOutput {
+		case "json":
+			err = audit.WriteJSON(os.Stdout, findings)
+		case "sarif":
+			err = audit.WriteSARIF(os.Stdout, findings)
+		default:
+			printAuditFindings(findings)
+		}
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to write report: %v", err))
+			os.Exit(1)
+		}
+
+		if auditFailOnFindings && len(findings) > 0 {
+			os.Exit(2)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditPermissionsCmd)
+	auditPermissionsCmd.Flags().StringVar(&auditRulesFile, "rules", "", "YAML rule configuration")
+	auditPermissionsCmd.Flags().StringVarP(&auditOutput, "output", "o", "table", "Output format: table, json or sarif")
+	auditPermissionsCmd.Flags().BoolVar(&auditFailOnFindings, "fail-on-findings", false, "Exit with code 2 when any finding is reported")
+}
+
+func printAuditFindings(findings []audit.Finding) {
+	if len(findings) == 0 {
+		ui.PrintSuccess("No findings.")
+		return
+	}
+
+	var rows [][]string
+	var errors []bool
+	for _, f := range findings {
+		rows = append(rows, []string{f.RuleID, f.Severity, f.Securable.FullName, f.Principal, f.Message})
+		errors = append(errors, f.Severity == audit.SeverityError)
+	}
+	ui.PrintTableWithHighlights([]string{"Rule", "Severity", "Securable", "Principal", "Finding"}, rows, errors)
+	ui.PrintInfo(fmt.Sprintf("%d findings.", len(findings)))
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
+	pkgcatalog "dbx-explore/pkg/catalog"
 	"dbx-explore/pkg/ui"
 
 	"github.com/databricks/databricks-sdk-go"
@@ -41,9 +43,277 @@ var listCatalogsCmd = &cobra.Command{
 	},
 }
 
+var describeTableCmd = &cobra.Command{
+	Use:   "describe-table <catalog.schema.table>",
+	Short: "Show everything Unity Catalog knows about a table or view",
+	Long: `Describe a table: overview, columns, partitioning and liquid clustering,
+primary and foreign keys, row filter and column masks, Delta settings, table
+properties and, for views, the highlighted view definition.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+
+		t, err := pkgcatalog.GetTable(ctx, w, args[0])
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to get table details: %v", err))
+			os.Exit(1)
+		}
+		printTableDescription(w, pkgcatalog.DescribeTable(*t))
+	},
+}
+
+var describeCatalogCmd = &cobra.Command{
+	Use:   "describe-catalog <catalog>",
+	Short: "Show everything Unity Catalog knows about a catalog",
+	Long: `Describe a catalog: type, owner, isolation mode, storage, the provider and
+share of Delta Sharing catalogs, the connection and options of foreign
+catalogs, properties and, for isolated catalogs, workspace bindings.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+
+		c, err := pkgcatalog.GetCatalog(ctx, w, args[0])
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to get catalog details: %v", err))
+			os.Exit(1)
+		}
+		printCatalogDescription(ctx, w, c)
+	},
+}
+
+var describeSchemaCmd = &cobra.Command{
+	Use:   "describe-schema <catalog.schema>",
+	Short: "Show everything Unity Catalog knows about a schema",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+
+		s, err := pkgcatalog.GetSchema(ctx, w, args[0])
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to get schema details: %v", err))
+			os.Exit(1)
+		}
+		printSchemaDescription(s)
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(catalogCmd)
-	catalogCmd.AddCommand(listCatalogsCmd)
+	catalogCmd.AddCommand(listCatalogsCmd, describeCatalogCmd, describeSchemaCmd, describeTableCmd)
+}
+
+// printCatalogDescription prints the overview, options and properties of a
+// catalog, and the workspace bindings of an isolated one.
+func printCatalogDescription(ctx context.Context, w *databricks.WorkspaceClient, c *catalog.CatalogInfo) {
+	ui.PrintHeader(fmt.Sprintf("Catalog: %s", c.Name))
+	overview := [][]string{
+		{"Type", valueOrDash(string(c.CatalogType))},
+		{"Owner", c.Owner},
+		{"Comment", valueOrDash(c.Comment)},
+		{"Isolation Mode", valueOrDash(string(c.IsolationMode))},
+	}
+	if c.BrowseOnly {
+		overview = append(overview, []string{"Browse Only", "true"})
+	}
+	if c.ProviderName != "" || c.ShareName != "" {
+		overview = append(overview, []string{"Provider", valueOrDash(c.ProviderName)}, []string{"Share", valueOrDash(c.ShareName)})
+	}
+	if c.ConnectionName != "" {
+		overview = append(overview, []string{"Connection", c.ConnectionName})
+	}
+	overview = append(overview,
+		[]string{"Storage Root", valueOrDash(c.StorageRoot)},
+		[]string{"Storage Location", valueOrDash(c.StorageLocation)},
+		[]string{"Predictive Optimization", predictiveOptimization(c.EffectivePredictiveOptimizationFlag, c.EnablePredictiveOptimization)},
+		[]string{"Created", fmt.Sprintf("%s by %s", formatMillis(c.CreatedAt), valueOrDash(c.CreatedBy))},
+		[]string{"Updated", fmt.Sprintf("%s by %s", formatMillis(c.UpdatedAt), valueOrDash(c.UpdatedBy))},
+		[]string{"Metastore ID", valueOrDash(c.MetastoreId)},
+	)
+	ui.PrintTable([]string{"Property", "Value"}, overview)
+
+	if len(c.Options) > 0 {
+		ui.PrintHeader("Options")
+		ui.PrintTable([]string{"Key", "Value"}, sortedMapRows(c.Options))
+	}
+	if len(c.Properties) > 0 {
+		ui.PrintHeader("Properties")
+		ui.PrintTable([]string{"Key", "Value"}, sortedMapRows(c.Properties))
+	}
+	if c.IsolationMode == catalog.CatalogIsolationModeIsolated {
+		ui.PrintHeader("Workspace Bindings")
+		if _, err := printWorkspaceBindings(ctx, w, c.Name); err != nil {
+			ui.PrintError(err.Error())
+		}
+	}
+}
+
+// printWorkspaceBindings prints the bindings of a catalog, marking the
+// current workspace, and returns them.
+func printWorkspaceBindings(ctx context.Context, w *databricks.WorkspaceClient, catalogName string) ([]catalog.WorkspaceBinding, error) {
+	bindings, err := pkgcatalog.GetWorkspaceBindings(ctx, w, catalogName)
+	if err != nil {
+		return nil, err
+	}
+	if len(bindings) == 0 {
+		ui.PrintInfo("Not bound to any workspace.")
+		return nil, nil
+	}
+	current, _ := w.CurrentWorkspaceID(ctx)
+	rows := make([][]string, len(bindings))
+	for i, b := range bindings {
+		mark := ""
+		if b.WorkspaceId == current {
+			mark = "✓"
+		}
+		rows[i] = []string{fmt.Sprintf("%d", b.WorkspaceId), pkgcatalog.BindingLabel(b.BindingType), mark}
+	}
+	ui.PrintTable([]string{"Workspace ID", "Access", "Current"}, rows)
+	return bindings, nil
+}
+
+// printSchemaDescription prints the overview and properties of a schema.
+func printSchemaDescription(s *catalog.SchemaInfo) {
+	ui.PrintHeader(fmt.Sprintf("Schema: %s", s.FullName))
+	overview := [][]string{
+		{"Owner", s.Owner},
+		{"Comment", valueOrDash(s.Comment)},
+		{"Catalog Type", valueOrDash(string(s.CatalogType))},
+		{"Storage Root", valueOrDash(s.StorageRoot)},
+		{"Storage Location", valueOrDash(s.StorageLocation)},
+		{"Predictive Optimization", predictiveOptimization(s.EffectivePredictiveOptimizationFlag, s.EnablePredictiveOptimization)},
+		{"Created", fmt.Sprintf("%s by %s", formatMillis(s.CreatedAt), valueOrDash(s.CreatedBy))},
+		{"Updated", fmt.Sprintf("%s by %s", formatMillis(s.UpdatedAt), valueOrDash(s.UpdatedBy))},
+		{"Schema ID", valueOrDash(s.SchemaId)},
+	}
+	if s.BrowseOnly {
+		overview = append(overview, []string{"Browse Only", "true"})
+	}
+	ui.PrintTable([]string{"Property", "Value"}, overview)
+
+	if len(s.Properties) > 0 {
+		ui.PrintHeader("Properties")
+		ui.PrintTable([]string{"Key", "Value"}, sortedMapRows(s.Properties))
+	}
+}
+
+// predictiveOptimization prints the effective setting and where it comes from.
+func predictiveOptimization(flag *catalog.EffectivePredictiveOptimizationFlag, enable catalog.EnablePredictiveOptimization) string {
+	if flag != nil {
+		if flag.InheritedFromName != "" {
+			return fmt.Sprintf("%s (inherited from %s)", flag.Value, flag.InheritedFromName)
+		}
+		return string(flag.Value)
+	}
+	return valueOrDash(string(enable))
+}
+
+// printTableDescription prints every section of a describe view. Foreign key
+// targets are listed with their Catalog Explorer link.
+func printTableDescription(w *databricks.WorkspaceClient, d *pkgcatalog.TableDescription) {
+	t := d.Info
+	ui.PrintHeader(fmt.Sprintf("Table: %s", t.FullName))
+	overview := [][]string{
+		{"Type", string(t.TableType)},
+		{"Format", valueOrDash(string(t.DataSourceFormat))},
+		{"Owner", t.Owner},
+		{"Comment", valueOrDash(t.Comment)},
+		{"Storage Location", valueOrDash(t.StorageLocation)},
+		{"Storage Credential", valueOrDash(t.StorageCredentialName)},
+		{"Created", fmt.Sprintf("%s by %s", formatMillis(t.CreatedAt), valueOrDash(t.CreatedBy))},
+		{"Updated", fmt.Sprintf("%s by %s", formatMillis(t.UpdatedAt), valueOrDash(t.UpdatedBy))},
+		{"Table ID", t.TableId},
+	}
+	if t.PipelineId != "" {
+		overview = append(overview, []string{"Pipeline ID", t.PipelineId})
+	}
+	ui.PrintTable([]string{"Property", "Value"}, overview)
+
+	ui.PrintHeader("Columns")
+	var cols [][]string
+	for _, c := range t.Columns {
+		cols = append(cols, []string{c.Name, c.TypeText, fmt.Sprintf("%v", c.Nullable), valueOrDash(c.Comment)})
+	}
+	ui.PrintTable([]string{"Column", "Type", "Nullable", "Comment"}, cols)
+
+	if len(d.PartitionColumns) > 0 || len(d.ClusteringColumns) > 0 {
+		ui.PrintHeader("Layout")
+		ui.PrintTable([]string{"Layout", "Columns"}, [][]string{
+			{"Partitioned By", valueOrDash(strings.Join(d.PartitionColumns, ", "))},
+			{"Clustered By", valueOrDash(strings.Join(d.ClusteringColumns, ", "))},
+		})
+	}
+
+	if d.PrimaryKey != nil || len(d.ForeignKeys) > 0 {
+		ui.PrintHeader("Constraints")
+		var rows [][]string
+		if pk := d.PrimaryKey; pk != nil {
+			rows = append(rows, []string{"PRIMARY KEY", pk.Name, strings.Join(pk.ChildColumns, ", "), "-"})
+		}
+		for _, fk := range d.ForeignKeys {
+			rows = append(rows, []string{"FOREIGN KEY", fk.Name, strings.Join(fk.ChildColumns, ", "),
+				fmt.Sprintf("%s(%s)", fk.ParentTable, strings.Join(fk.ParentColumns, ", "))})
+		}
+		ui.PrintTable([]string{"Constraint", "Name", "Columns", "References"}, rows)
+		for _, fk := range d.ForeignKeys {
+			fmt.Printf("  🔗 %s: %s\n", fk.ParentTable, exploreURL(w, fk.ParentTable))
+		}
+	}
+
+	if d.RowFilter != nil || len(d.ColumnMasks) > 0 {
+		ui.PrintHeader("Row Filter & Column Masks")
+		var rows [][]string
+		if f := d.RowFilter; f != nil {
+			rows = append(rows, []string{"Row Filter", "*", f.FunctionName, strings.Join(f.InputColumnNames, ", ")})
+		}
+		for _, m := range d.ColumnMasks {
+			rows = append(rows, []string{"Column Mask", m.Column, m.FunctionName, valueOrDash(strings.Join(m.UsingColumnNames, ", "))})
+		}
+		ui.PrintTable([]string{"Policy", "Column", "Function", "Using Columns"}, rows)
+	}
+
+	if len(d.DeltaSettings) > 0 {
+		ui.PrintHeader("Delta Settings")
+		ui.PrintTable([]string{"Setting", "Value"}, settingRows(d.DeltaSettings))
+	}
+	if len(d.Properties) > 0 {
+		ui.PrintHeader("Properties")
+		ui.PrintTable([]string{"Key", "Value"}, settingRows(d.Properties))
+	}
+
+	if t.ViewDefinition != "" {
+		ui.PrintHeader("View Definition")
+		ui.PrintCode(t.ViewDefinition, "sql")
+		if t.ViewDependencies != nil {
+			var deps []string
+			for _, dep := range t.ViewDependencies.Dependencies {
+				switch {
+				case dep.Table != nil:
+					deps = append(deps, dep.Table.TableFullName)
+				case dep.Function != nil:
+					deps = append(deps, dep.Function.FunctionFullName+"()")
+				}
+			}
+			if len(deps) > 0 {
+				ui.PrintInfo(fmt.Sprintf("Depends on: %s", strings.Join(deps, ", ")))
+			}
+		}
+	}
+}
+
+func settingRows(settings []pkgcatalog.Setting) [][]string {
+	rows := make([][]string, len(settings))
+	for i, s := range settings {
+		rows[i] = []string{s.Name, s.Value}
+	}
+	return rows
+}
+
+// exploreURL links to a securable in the workspace Catalog Explorer.
+func exploreURL(w *databricks.WorkspaceClient, fullName string) string {
+	return strings.TrimSuffix(w.Config.Host, "/") + "/explore/data/" + strings.ReplaceAll(fullName, ".", "/")
 }
 
 func getWorkspaceClient() *databricks.WorkspaceClient {
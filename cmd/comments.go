@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	pkgcatalog "dbx-explore/pkg/catalog"
+	"dbx-explore/pkg/query"
+	"dbx-explore/pkg/ui"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/spf13/cobra"
+)
+
+var (
+	commentsFile   string
+	commentsDryRun bool
+	commentsYes    bool
+)
+
+var commentsCmd = &cobra.Command{
+	Use:   "comments",
+	Short: "Manage table and column comments",
+}
+
+var commentsImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Apply table and column comments from a CSV file",
+	Long: `Apply comments from a CSV file with a header row of table, column and
+comment (in any order). Leave column empty to set the table comment.
+
+	table,column,comment
+	main.sales.orders,,One row per order
+	main.sales.orders,customer_id,Buyer of the order
+
+Comments that already match are skipped. Changes are applied with COMMENT ON
+and ALTER TABLE ... ALTER COLUMN ... COMMENT on the configured SQL warehouse
+(DATABRICKS_WAREHOUSE_ID).`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		f, err := os.Open(commentsFile)
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to open %s: %v", commentsFile, err))
+			os.Exit(1)
+		}
+		entries, err := query.ReadCommentsCSV(f)
+		f.Close()
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		w := getWorkspaceClient()
+		changes, err := planComments(ctx, w, entries)
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+		if len(changes) == 0 {
+			ui.PrintSuccess("No changes. All comments are up to date.")
+			return
+		}
+
+		rows := make([][]string, len(changes))
+		for i, c := range changes {
+			rows[i] = []string{c.Table, valueOrDash(c.Column), ui.Truncate(valueOrDash(c.Old), ui.MaxCellWidth), ui.Truncate(valueOrDash(c.Comment), ui.MaxCellWidth)}
+		}
+		ui.PrintTable([]string{"Table", "Column", "Current", "New"}, rows)
+		ui.PrintInfo(fmt.Sprintf("%d of %d comments change.", len(changes), len(entries)))
+		if commentsDryRun {
+			return
+		}
+		warehouseID := configuredWarehouseID(true)
+		if !commentsYes && !ui.ConfirmPrompt("Apply these changes") {
+			ui.PrintInfo("Aborted.")
+			return
+		}
+
+		failed := 0
+		for _, c := range changes {
+			if err := query.SetComment(ctx, w, warehouseID, c.Table, c.Column, c.Comment); err != nil {
+				ui.PrintError(fmt.Sprintf("Line %d (%s): %v", c.Line, commentTarget(c.Table, c.Column), err))
+				failed++
+			}
+		}
+		if failed > 0 {
+			ui.PrintError(fmt.Sprintf("%d of %d comments failed.", failed, len(changes)))
+			os.Exit(1)
+		}
+		ui.PrintSuccess(fmt.Sprintf("Updated %d comments.", len(changes)))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(commentsCmd)
+	commentsCmd.AddCommand(commentsImportCmd)
+	commentsImportCmd.Flags().StringVarP(&commentsFile, "file", "f", "", "CSV file with table, column and comment")
+	commentsImportCmd.Flags().BoolVar(&commentsDryRun, "dry-run", false, "Show the changes without applying them")
+	commentsImportCmd.Flags().BoolVarP(&commentsYes, "yes", "y", false, "Apply without asking for confirmation")
+	_ = commentsImportCmd.MarkFlagRequired("file")
+}
+
+// commentChange is a comment that differs from the live one.
+type commentChange struct {
+	query.CommentEntry
+	Old string
+}
+
+// planComments checks every entry against its table and returns the ones that
+// change a comment. Column names are matched case-insensitively.
+func planComments(ctx context.Context, w *databricks.WorkspaceClient, entries []query.CommentEntry) ([]commentChange, error) {
+	tables := map[string]*catalog.TableInfo{}
+	var changes []commentChange
+	for _, e := range entries {
+		key := strings.ToLower(e.Table)
+		t, ok := tables[key]
+		if !ok {
+			info, err := pkgcatalog.GetTable(ctx, w, e.Table)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: failed to get table %s: %w", e.Line, e.Table, err)
+			}
+			tables[key], t = info, info
+		}
+
+		old := t.Comment
+		if e.Column != "" {
+			found := false
+			for _, col := range t.Columns {
+				if strings.EqualFold(col.Name, e.Column) {
+					e.Column, old, found = col.Name, col.Comment, true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("line %d: column %s not found in %s", e.Line, e.Column, e.Table)
+			}
+		}
+		if old != e.Comment {
+			changes = append(changes, commentChange{CommentEntry: e, Old: old})
+		}
+	}
+	return changes, nil
+}
+
+func commentTarget(table, column string) string {
+	if column == "" {
+		return table
+	}
+	return table + "." + column
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"dbx-explore/pkg/coverage"
+	"dbx-explore/pkg/identity"
+	"dbx-explore/pkg/query"
+	"dbx-explore/pkg/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	coverageRequiredTags []string
+	coverageOutput       string
+)
+
+var coverageCmd = &cobra.Command{
+	Use:   "coverage <catalog>...",
+	Short: "Report documentation coverage per catalog and schema",
+	Long: `Compute, per catalog and schema, the percentage of tables and columns with
+a comment, of objects owned by a group rather than an individual, and of
+tables carrying every --require-tag. The score is the mean of these
+percentages; catalogs and schemas are ranked from best to worst.
+
+Required tags are read on the configured SQL warehouse
+(DATABRICKS_WAREHOUSE_ID). Use -o json to keep a history and chart it.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if coverageOutput != "table" && coverageOutput != "json" {
+			ui.PrintError(fmt.Sprintf("Unknown output format %q (use table or json).", coverageOutput))
+			os.Exit(1)
+		}
+		w := getWorkspaceClient()
+
+		var tags coverage.TagSource
+		if len(coverageRequiredTags) > 0 {
+			warehouseID := configuredWarehouseID(true)
+			tags = func(ctx context.Context, catalogName string) (map[string]map[string]string, error) {
+				return query.CatalogTags(ctx, w, warehouseID, catalogName)
+			}
+		}
+
+		dir := identity.NewDirectory(w)
+		report, err := coverage.Collect(context.Background(), w, args, coverageRequiredTags, tags, dir.Lookup, func(name string) {
+			if coverageOutput == "table" {
+				ui.PrintInfo(fmt.Sprintf("Reading catalog %s...", name))
+			}
+		})
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		if coverageOutput == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(report); err != nil {
+				ui.PrintError(fmt.Sprintf("Failed to encode report: %v", err))
+				os.Exit(1)
+			}
+			return
+		}
+		ui.PrintHeader("Catalogs")
+		printCoverage(report.Catalogs, len(coverageRequiredTags) > 0)
+		ui.PrintHeader("Schemas")
+		printCoverage(report.Schemas, len(coverageRequiredTags) > 0)
+		if len(coverageRequiredTags) > 0 {
+			ui.PrintInfo(fmt.Sprintf("Required tags: %s", strings.Join(coverageRequiredTags, ", ")))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(coverageCmd)
+	coverageCmd.Flags().StringSliceVar(&coverageRequiredTags, "require-tag", nil, "Tag key every table must carry; repeatable")
+	coverageCmd.Flags().StringVarP(&coverageOutput, "output", "o", "table", "Output format: table or json")
+}
+
+// printCoverage prints one ranked row per scope with percentages and counts.
+func printCoverage(scores []coverage.Score, withTags bool) {
+	headers := []string{"#", "Scope", "Score", "Table Comments", "Column Comments", "Group Owners"}
+	if withTags {
+		headers = append(headers, "Required Tags")
+	}
+	rows := make([][]string, len(scores))
+	for i, s := range scores {
+		c := s.Counts
+		rows[i] = []string{
+			fmt.Sprintf("%d", i+1),
+			s.Scope,
+			fmt.Sprintf("%.1f", s.Score),
+			coverageCell(s.TableComments, c.TablesCommented, c.Tables),
+			coverageCell(s.ColumnComments, c.ColumnsCommented, c.Columns),
+			coverageCell(s.GroupOwners, c.GroupOwners, c.Owners),
+		}
+		if withTags && s.RequiredTags != nil {
+			rows[i] = append(rows[i], coverageCell(*s.RequiredTags, c.TablesTagged, c.Tables))
+		}
+	}
+	ui.PrintTable(headers, rows)
+}
+
+func coverageCell(pct float64, n, total int) string {
+	return fmt.Sprintf("%5.1f%% (%d/%d)", pct, n, total)
+}
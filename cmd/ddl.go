@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	pkgcatalog "dbx-explore/pkg/catalog"
+	"dbx-explore/pkg/ui"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ddlComment         string
+	ddlStorageRoot     string
+	ddlStorageLocation string
+	ddlConnection      string
+	ddlOptions         []string
+	ddlProvider        string
+	ddlShare           string
+	ddlProperties      []string
+	ddlForce           bool
+	ddlDryRun          bool
+	ddlYes             bool
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create catalogs, schemas and volumes",
+	Long: `Create Unity Catalog objects through the REST API. The exact request and
+the equivalent databricks CLI command are shown before anything is sent; use
+--dry-run to only print them.`,
+}
+
+var createCatalogCmd = &cobra.Command{
+	Use:   "catalog <name>",
+	Short: "Create a managed, foreign or shared catalog",
+	Long: `Create a catalog:
+
+  managed   optionally with --storage-root
+  foreign   --connection <connection> and --option key=value for the source,
+            e.g. --option database=shop
+  shared    --provider <provider> --share <share> (Delta Sharing)`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runOperation(pkgcatalog.CreateCatalog(catalog.CreateCatalog{
+			Name:           args[0],
+			Comment:        ddlComment,
+			StorageRoot:    ddlStorageRoot,
+			ConnectionName: ddlConnection,
+			Options:        mustParseKeyValues("--option", ddlOptions),
+			ProviderName:   ddlProvider,
+			ShareName:      ddlShare,
+			Properties:     mustParseKeyValues("--property", ddlProperties),
+		}))
+	},
+}
+
+var createSchemaCmd = &cobra.Command{
+	Use:   "schema <catalog.schema>",
+	Short: "Create a schema, optionally with a managed location",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		parts := strings.Split(args[0], ".")
+		if len(parts) != 2 {
+			ui.PrintError(fmt.Sprintf("Expected <catalog.schema>, got %q.", args[0]))
+			os.Exit(1)
+		}
+		runOperation(pkgcatalog.CreateSchema(catalog.CreateSchema{
+			CatalogName: parts[0],
+			Name:        parts[1],
+			Comment:     ddlComment,
+			StorageRoot: ddlStorageRoot,
+			Properties:  mustParseKeyValues("--property", ddlProperties),
+		}))
+	},
+}
+
+var createVolumeCmd = &cobra.Command{
+	Use:   "volume <catalog.schema.volume>",
+	Short: "Create a managed volume, or an external one with --storage-location",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		parts := strings.Split(args[0], ".")
+		if len(parts) != 3 {
+			ui.PrintError(fmt.Sprintf("Expected <catalog.schema.volume>, got %q.", args[0]))
+			os.Exit(1)
+		}
+		volumeType := catalog.VolumeTypeManaged
+		if ddlStorageLocation != "" {
+			volumeType = catalog.VolumeTypeExternal
+		}
+		runOperation(pkgcatalog.CreateVolume(catalog.CreateVolumeRequestContent{
+			CatalogName:     parts[0],
+			SchemaName:      parts[1],
+			Name:            parts[2],
+			VolumeType:      volumeType,
+			StorageLocation: ddlStorageLocation,
+			Comment:         ddlComment,
+		}))
+	},
+}
+
+var renameCmd = &cobra.Command{
+	Use:   "rename <type> <full-name> <new-name>",
+	Short: "Rename a catalog, schema, volume or registered model",
+	Long: `Rename a catalog, schema, volume or registered model. The new name is the
+last part only, e.g. "rename schema main.sales sales_v2".`,
+	Args: cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		runOperation(pkgcatalog.Rename(mustParseObjectKind(args[0]), args[1], args[2]))
+	},
+}
+
+var dropCmd = &cobra.Command{
+	Use:   "drop <type> <full-name>",
+	Short: "Drop a catalog, schema, table, view, volume, function or registered model",
+	Long: `Drop an object. Without --yes the full name must be typed to confirm.
+--force also drops a catalog or schema that is not empty.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		op, err := pkgcatalog.Drop(mustParseObjectKind(args[0]), args[1], ddlForce)
+		runOperationConfirmed(op, err, args[1])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(createCmd, renameCmd, dropCmd)
+	createCmd.AddCommand(createCatalogCmd, createSchemaCmd, createVolumeCmd)
+
+	for _, c := range []*cobra.Command{createCatalogCmd, createSchemaCmd, createVolumeCmd} {
+		c.Flags().StringVar(&ddlComment, "comment", "", "Comment")
+	}
+	for _, c := range []*cobra.Command{createCatalogCmd, createSchemaCmd} {
+		c.Flags().StringVar(&ddlStorageRoot, "storage-root", "", "Managed storage location, e.g. s3://bucket/path")
+		c.Flags().StringArrayVar(&ddlProperties, "property", nil, "Property key=value; repeatable")
+	}
+	createCatalogCmd.Flags().StringVar(&ddlConnection, "connection", "", "Connection of a foreign catalog")
+	createCatalogCmd.Flags().StringArrayVar(&ddlOptions, "option", nil, "Foreign catalog option key=value; repeatable")
+	createCatalogCmd.Flags().StringVar(&ddlProvider, "provider", "", "Delta Sharing provider of a shared catalog")
+	createCatalogCmd.Flags().StringVar(&ddlShare, "share", "", "Delta Sharing share of a shared catalog")
+	createVolumeCmd.Flags().StringVar(&ddlStorageLocation, "storage-location", "", "Location of an external volume")
+	dropCmd.Flags().BoolVar(&ddlForce, "force", false, "Also drop a non-empty catalog or schema")
+
+	for _, c := range []*cobra.Command{createCatalogCmd, createSchemaCmd, createVolumeCmd, renameCmd, dropCmd} {
+		c.Flags().BoolVar(&ddlDryRun, "dry-run", false, "Print the API request and the equivalent databricks CLI command without sending it")
+		c.Flags().BoolVarP(&ddlYes, "yes", "y", false, "Apply without asking for confirmation")
+	}
+}
+
+// mustParseKeyValues parses repeated key=value flags. Values are split at the
+// first "=" only, so they may contain commas and further "=".
+func mustParseKeyValues(flag string, pairs []string) map[string]string {
+	if len(pairs) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			ui.PrintError(fmt.Sprintf("Invalid %s %q: expected key=value.", flag, pair))
+			os.Exit(1)
+		}
+		m[strings.TrimSpace(k)] = v
+	}
+	return m
+}
+
+// mustParseObjectKind accepts the object kinds that can be renamed or dropped.
+func mustParseObjectKind(s string) pkgcatalog.ObjectKind {
+	kind := pkgcatalog.ObjectKind(strings.ToLower(s))
+	switch kind {
+	case pkgcatalog.KindCatalog, pkgcatalog.KindSchema, pkgcatalog.KindTable, pkgcatalog.KindView,
+		pkgcatalog.KindVolume, pkgcatalog.KindFunction, pkgcatalog.KindModel:
+		return kind
+	}
+	ui.PrintError(fmt.Sprintf("Unknown object type %q (use catalog, schema, table, view, volume, function or model).", s))
+	os.Exit(1)
+	return ""
+}
+
+func runOperation(op *pkgcatalog.Operation, err error) {
+	runOperationConfirmed(op, err, "")
+}
+
+// runOperationConfirmed prints the request and, unless --dry-run, applies it
+// after confirmation. With confirmName set, the name must be typed.
+func runOperationConfirmed(op *pkgcatalog.Operation, err error, confirmName string) {
+	if err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+	printOperation(op)
+	if ddlDryRun {
+		return
+	}
+	if !ddlYes && !confirmOperation(op, confirmName) {
+		return
+	}
+	if err := op.Apply(context.Background(), getWorkspaceClient()); err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+	ui.PrintSuccess(op.Summary + ": done.")
+}
+
+// printOperation shows the exact API request and the equivalent databricks
+// CLI command.
+func printOperation(op *pkgcatalog.Operation) {
+	ui.PrintHeader(op.Summary)
+	fmt.Println(op.Request())
+	fmt.Println()
+	ui.PrintInfo("Equivalent CLI command:")
+	fmt.Println(op.CLI())
+}
+
+func confirmOperation(op *pkgcatalog.Operation, confirmName string) bool {
+	if confirmName != "" {
+		input, err := ui.InputPrompt(fmt.Sprintf("Type %s to confirm", confirmName), "")
+		if err == nil && input == confirmName {
+			return true
+		}
+	} else if ui.ConfirmPrompt(op.Summary) {
+		return true
+	}
+	ui.PrintInfo("Aborted.")
+	return false
+}
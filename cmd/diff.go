@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	pkgcatalog "dbx-explore/pkg/catalog"
+	"dbx-explore/pkg/diff"
+	"dbx-explore/pkg/ui"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/spf13/cobra"
+)
+
+// exitDifferences is the exit code of diff --exit-code when the sides differ.
+const exitDifferences = 2
+
+var (
+	diffLeftProfile  string
+	diffRightProfile string
+	diffNoGrants     bool
+	diffOutput       string
+	diffContext      int
+	diffExitCode     bool
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <left> <right>",
+	Short: "Compare two schemas or catalogs object by object",
+	Long: `Compare two schemas (catalog.schema) or two catalogs, possibly in different
+workspaces: missing tables, column additions, removals and type changes,
+comments, properties and direct grants.
+
+The output is a colored unified diff, or with -o json a list of add, remove
+and replace changes that turn the left side into the right side, with paths
+addressing objects by name (not an RFC 6902 JSON Patch). Use --left-profile and
+--right-profile to read each side with a profile from ~/.databrickscfg.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		if diffOutput != "diff" && diffOutput != "json" {
+			ui.PrintError(fmt.Sprintf("Unknown output format %q (use diff or json).", diffOutput))
+			os.Exit(1)
+		}
+
+		left := loadDiffSide(ctx, diffLeftProfile, args[0])
+		right := loadDiffSide(ctx, diffRightProfile, args[1])
+		changes, err := pkgcatalog.DiffModels(left, right)
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		if diffOutput == "json" {
+			if changes == nil {
+				changes = []pkgcatalog.Change{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(changes); err != nil {
+				ui.PrintError(fmt.Sprintf("Failed to encode changes: %v", err))
+				os.Exit(1)
+			}
+		} else {
+			lines := diff.Unified(pkgcatalog.RenderModel(left), pkgcatalog.RenderModel(right),
+				diffSideName(diffLeftProfile, args[0]), diffSideName(diffRightProfile, args[1]), diffContext)
+			if len(lines) == 0 {
+				ui.PrintSuccess("No differences.")
+			} else {
+				ui.PrintUnifiedDiff(lines)
+				ui.PrintInfo(fmt.Sprintf("%d differences.", len(changes)))
+			}
+		}
+
+		if diffExitCode && len(changes) > 0 {
+			os.Exit(exitDifferences)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringVar(&diffLeftProfile, "left-profile", "", "Config profile for the left side (default: current credentials)")
+	diffCmd.Flags().StringVar(&diffRightProfile, "right-profile", "", "Config profile for the right side (default: current credentials)")
+	diffCmd.Flags().BoolVar(&diffNoGrants, "no-grants", false, "Skip comparing direct grants")
+	diffCmd.Flags().StringVarP(&diffOutput, "output", "o", "diff", "Output format: diff or json")
+	diffCmd.Flags().IntVar(&diffContext, "context", 3, "Lines of context in the unified diff")
+	diffCmd.Flags().BoolVar(&diffExitCode, "exit-code", false, "Exit with code 2 when there are differences")
+}
+
+// loadDiffSide loads the model of one side of a diff or exits.
+func loadDiffSide(ctx context.Context, profile, scope string) *pkgcatalog.ScopeModel {
+	w := workspaceClientForProfile(profile)
+	if diffOutput == "diff" {
+		ui.PrintInfo(fmt.Sprintf("Reading %s...", diffSideName(profile, scope)))
+	}
+	m, err := pkgcatalog.LoadScopeModel(ctx, w, scope, !diffNoGrants)
+	if err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+	return m
+}
+
+func diffSideName(profile, scope string) string {
+	if profile == "" {
+		return scope
+	}
+	return profile + ":" + scope
+}
+
+// workspaceClientForProfile returns a client for a ~/.databrickscfg profile,
+// or the default client when profile is empty. Environment credentials are
+// ignored for profiles so they cannot mix with the profile's workspace.
+func workspaceClientForProfile(profile string) *databricks.WorkspaceClient {
+	if profile == "" {
+		return getWorkspaceClient()
+	}
+	w, err := databricks.NewWorkspaceClient(&databricks.Config{Profile: profile, Loaders: []config.Loader{config.ConfigFile}})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize Databricks Client for profile %s: %v\n", profile, err)
+		os.Exit(1)
+	}
+	return w
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"dbx-explore/pkg/docs"
+	"dbx-explore/pkg/query"
+	"dbx-explore/pkg/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	docsCatalogs []string
+	docsSchema   string
+	docsOut      string
+	docsFormat   string
+	docsTitle    string
+)
+
+var docsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate documentation for Unity Catalog objects",
+}
+
+var docsGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a static data dictionary site",
+	Long: `Generate a static site with one page per catalog, schema and table showing
+columns, types, comments, tags, owners, constraints and links to Catalog
+Explorer and lineage, plus a client-side search index.
+
+Tags are read from information_schema on the configured SQL warehouse
+(DATABRICKS_WAREHOUSE_ID); without a warehouse they are left out.
+The HTML site works when opened straight from the file system.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+
+		var tags docs.TagSource
+		if warehouseID := configuredWarehouseID(false); warehouseID != "" {
+			tags = func(ctx context.Context, catalogName string) (map[string]map[string]string, error) {
+				return query.CatalogTags(ctx, w, warehouseID, catalogName)
+			}
+		} else {
+			ui.PrintInfo("DATABRICKS_WAREHOUSE_ID is not set; tags will be left out.")
+		}
+
+		site, err := docs.Collect(ctx, w, docsCatalogs, docsSchema, tags, func(name string) {
+			ui.PrintInfo(fmt.Sprintf("Reading catalog %s...", name))
+		})
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to read catalogs: %v", err))
+			os.Exit(1)
+		}
+		if docsTitle != "" {
+			site.Title = docsTitle
+		}
+
+		format := docs.Format(docsFormat)
+		pages, err := docs.Generate(site, docsOut, format)
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+		index := "index.html"
+		if format == docs.FormatMarkdown {
+			index = "index.md"
+		}
+		ui.PrintSuccess(fmt.Sprintf("Wrote %d pages to %s", pages, filepath.Join(docsOut, index)))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(docsCmd)
+	docsCmd.AddCommand(docsGenerateCmd)
+
+	docsGenerateCmd.Flags().StringSliceVar(&docsCatalogs, "catalog", nil, "Catalog to document; repeatable")
+	docsGenerateCmd.Flags().StringVar(&docsSchema, "schema", "", "Only document this schema")
+	docsGenerateCmd.Flags().StringVar(&docsOut, "out", "./site", "Output directory")
+	docsGenerateCmd.Flags().StringVar(&docsFormat, "format", "html", "Output format: html or markdown")
+	docsGenerateCmd.Flags().StringVar(&docsTitle, "title", "", "Site title (default: Data Dictionary)")
+	_ = docsGenerateCmd.MarkFlagRequired("catalog")
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	pkgcatalog "dbx-explore/pkg/catalog"
+	"dbx-explore/pkg/ui"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/spf13/cobra"
+)
+
+var (
+	erdTables   []string
+	erdDepth    int
+	erdKeysOnly bool
+	erdOutput   string
+)
+
+var erdCmd = &cobra.Command{
+	Use:   "erd <catalog.schema>",
+	Short: "Export an entity-relationship diagram from primary and foreign keys",
+	Long: `Build an entity-relationship diagram of a schema from its informational
+PRIMARY KEY and FOREIGN KEY constraints and print it as Mermaid, PlantUML or
+Graphviz DOT.
+
+Use --table (repeatable) to limit the diagram to some tables and the tables
+within --depth relations of them. Parent tables in other schemas are shown
+without columns. Views are left out.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		if erdOutput != "mermaid" && erdOutput != "plantuml" && erdOutput != "dot" {
+			ui.PrintError(fmt.Sprintf("Unknown output format %q (use mermaid, plantuml or dot).", erdOutput))
+			os.Exit(1)
+		}
+		parts := strings.Split(args[0], ".")
+		if len(parts) != 2 {
+			ui.PrintError(fmt.Sprintf("Expected <catalog.schema>, got %q.", args[0]))
+			os.Exit(1)
+		}
+		w := getWorkspaceClient()
+
+		infos, err := pkgcatalog.ListTables(ctx, w, parts[0], parts[1])
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to list tables: %v", err))
+			os.Exit(1)
+		}
+		var tables []catalog.TableInfo
+		for _, t := range infos {
+			if t.TableType != catalog.TableTypeView {
+				tables = append(tables, t)
+			}
+		}
+
+		erd := pkgcatalog.BuildERD(tables)
+		if len(erdTables) > 0 {
+			if erd, err = erd.Filter(erdTables, erdDepth); err != nil {
+				ui.PrintError(err.Error())
+				os.Exit(1)
+			}
+		}
+		if erdKeysOnly {
+			erd = erd.KeysOnly()
+		}
+		if len(erd.Relations) == 0 {
+			fmt.Fprintf(os.Stderr, "No foreign keys found in %s.\n", args[0])
+		}
+
+		switch erdOutput {
+		case "plantuml":
+			fmt.Print(erd.RenderPlantUML())
+		case "dot":
+			fmt.Print(erd.RenderDOT())
+		default:
+			fmt.Print(erd.RenderMermaid())
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(erdCmd)
+	erdCmd.Flags().StringSliceVar(&erdTables, "table", nil, "Only include this table and its neighbors; repeatable")
+	erdCmd.Flags().IntVar(&erdDepth, "depth", 1, "How many relations away from --table to include")
+	erdCmd.Flags().BoolVar(&erdKeysOnly, "keys-only", false, "Only show primary and foreign key columns")
+	erdCmd.Flags().StringVarP(&erdOutput, "output", "o", "mermaid", "Output format: mermaid, plantuml or dot")
+}
@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	pkgcatalog "dbx-explore/pkg/catalog"
+	"dbx-explore/pkg/ui"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/spf13/cobra"
+)
+
+var (
+	grantsEffective  bool
+	grantsPrincipals []string
+	grantsPrivileges []string
+	grantsDryRun     bool
+	grantsYes        bool
+
+	grantsManifestFile string
+	grantsOutput       string
+	grantsAutoApprove  bool
+)
+
+// Exit codes for "grants plan", following terraform's -detailed-exitcode so CI
+// jobs can tell drift apart from failures.
+const (
+	exitPlanNoChanges = 0
+	exitPlanError     = 1
+	exitPlanChanges   = 2
+)
+
+var grantsCmd = &cobra.Command{
+	Use:   "grants",
+	Short: "Show, grant and revoke Unity Catalog privileges",
+}
+
+var grantsShowCmd = &cobra.Command{
+	Use:   "show <securable-type> <full-name>",
+	Short: "Show direct (or effective) grants on a securable",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+		securableType, fullName := mustParseSecurableType(args[0]), args[1]
+
+		if grantsEffective {
+			if err := printEffectivePermissions(ctx, w, securableType, fullName); err != nil {
+				ui.PrintError(err.Error())
+				os.Exit(1)
+			}
+			return
+		}
+
+		perms, err := pkgcatalog.GetPermissions(ctx, w, securableType, fullName)
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to get permissions: %v", err))
+			os.Exit(1)
+		}
+
+		printDirectGrants(perms.PrivilegeAssignments)
+	},
+}
+
+var grantsGrantCmd = &cobra.Command{
+	Use:   "grant <securable-type> <full-name>",
+	Short: "Grant privileges to one or more principals",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		runGrantChange(mustParseSecurableType(args[0]), args[1], false)
+	},
+}
+
+var grantsRevokeCmd = &cobra.Command{
+	Use:   "revoke <securable-type> <full-name>",
+	Short: "Revoke privileges from one or more principals",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		runGrantChange(mustParseSecurableType(args[0]), args[1], true)
+	},
+}
+
+var grantsExportCmd = &cobra.Command{
+	Use:   "export <catalog>[.<schema>]",
+	Short: "Export direct grants for a catalog or schema subtree to a YAML manifest",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+
+		ui.PrintInfo(fmt.Sprintf("Exporting grants under %s...", args[0]))
+		m, err := pkgcatalog.ExportManifest(ctx, w, args[0])
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Export failed: %v", err))
+			os.Exit(1)
+		}
+		if err := pkgcatalog.SaveManifest(grantsOutput, m); err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+		ui.PrintSuccess(fmt.Sprintf("Wrote %d securables to %s", len(m.Securables), grantsOutput))
+	},
+}
+
+var grantsPlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show the changes needed to make live grants match a manifest",
+	Long: `Compare a grants manifest against the live direct grants.
+
+Exit codes: 0 = no changes, 1 = error, 2 = changes pending (drift).`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+
+		plans, err := loadAndPlanManifest(ctx, w, grantsManifestFile)
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(exitPlanError)
+		}
+		if len(plans) == 0 {
+			ui.PrintSuccess("No changes. Live grants match the manifest.")
+			os.Exit(exitPlanNoChanges)
+		}
+		os.Exit(exitPlanChanges)
+	},
+}
+
+var grantsApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a grants manifest to the live state",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+
+		plans, err := loadAndPlanManifest(ctx, w, grantsManifestFile)
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+		if len(plans) == 0 {
+			ui.PrintSuccess("No changes. Live grants match the manifest.")
+			return
+		}
+		if !grantsAutoApprove && !ui.ConfirmPrompt("Apply these changes") {
+			ui.PrintInfo("Aborted.")
+			return
+		}
+
+		failed := 0
+		for _, p := range plans {
+			if _, err := pkgcatalog.UpdatePermissions(ctx, w, p.Securable.Type, p.Securable.FullName, p.Changes); err != nil {
+				ui.PrintError(fmt.Sprintf("Failed to update %s: %v", p.Securable.FullName, err))
+				failed++
+				continue
+			}
+			ui.PrintSuccess(fmt.Sprintf("Updated %s", p.Securable.FullName))
+		}
+		if failed > 0 {
+			ui.PrintError(fmt.Sprintf("%d of %d securables failed to update.", failed, len(plans)))
+			os.Exit(1)
+		}
+		ui.PrintSuccess(fmt.Sprintf("Applied changes to %d securables.", len(plans)))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(grantsCmd)
+	grantsCmd.AddCommand(grantsShowCmd, grantsGrantCmd, grantsRevokeCmd)
+	grantsCmd.AddCommand(grantsExportCmd, grantsPlanCmd, grantsApplyCmd)
+
+	grantsExportCmd.Flags().StringVarP(&grantsOutput, "output", "o", "grants.yaml", "Manifest file to write")
+	for _, c := range []*cobra.Command{grantsPlanCmd, grantsApplyCmd} {
+		c.Flags().StringVarP(&grantsManifestFile, "file", "f", "", "Grants manifest (YAML)")
+		_ = c.MarkFlagRequired("file")
+	}
+	grantsApplyCmd.Flags().BoolVar(&grantsAutoApprove, "auto-approve", false, "Apply without asking for confirmation")
+
+	grantsShowCmd.Flags().BoolVar(&grantsEffective, "effective", false, "Show effective (inherited) permissions instead of direct grants")
+
+	for _, c := range []*cobra.Command{grantsGrantCmd, grantsRevokeCmd} {
+		c.Flags().StringSliceVarP(&grantsPrincipals, "principal", "p", nil, "Principal (user, group or service principal); repeatable")
+		c.Flags().StringSliceVar(&grantsPrivileges, "privilege", nil, "Privilege such as SELECT or USE_SCHEMA; repeatable")
+		c.Flags().BoolVar(&grantsDryRun, "dry-run", false, "Preview the change without applying it")
+		c.Flags().BoolVarP(&grantsYes, "yes", "y", false, "Apply without asking for confirmation")
+		_ = c.MarkFlagRequired("principal")
+		_ = c.MarkFlagRequired("privilege")
+	}
+}
+
+func runGrantChange(securableType, fullName string, revoke bool) {
+	var privileges []catalog.Privilege
+	for _, p := range grantsPrivileges {
+		priv := pkgcatalog.ParsePrivilege(p)
+		if !pkgcatalog.KnownPrivilege(priv) {
+			ui.PrintError(fmt.Sprintf("Unknown privilege %q", p))
+			os.Exit(1)
+		}
+		privileges = append(privileges, priv)
+	}
+
+	ctx := context.Background()
+	w := getWorkspaceClient()
+
+	changes := pkgcatalog.BuildPermissionChanges(grantsPrincipals, privileges, revoke)
+	if err := applyPermissionChanges(ctx, w, securableType, fullName, changes, grantsDryRun, grantsYes); err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+}
+
+// mustParseSecurableType resolves a securable type or object kind argument
+// ("TABLE", "model", "external-location") and exits on unknown values.
+func mustParseSecurableType(s string) string {
+	securableType, err := pkgcatalog.ParseSecurableType(s)
+	if err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+	return securableType
+}
+
+// applyPermissionChanges previews the diff against the current direct grants,
+// asks for confirmation unless skipConfirm is set, and applies the update.
+func applyPermissionChanges(ctx context.Context, w *databricks.WorkspaceClient, securableType, fullName string, changes []catalog.PermissionsChange, dryRun, skipConfirm bool) error {
+	current, err := pkgcatalog.GetPermissions(ctx, w, securableType, fullName)
+	if err != nil {
+		return fmt.Errorf("failed to get current permissions: %w", err)
+	}
+
+	diffs := pkgcatalog.DiffPermissions(current.PrivilegeAssignments, changes)
+	ui.PrintHeader(fmt.Sprintf("Planned changes on %s (%s)", fullName, securableType))
+	printPermissionDiffs(diffs)
+
+	if !pkgcatalog.HasPermissionChanges(diffs) {
+		ui.PrintInfo("Nothing to change.")
+		return nil
+	}
+	if dryRun {
+		ui.PrintInfo("Dry run: no changes applied.")
+		return nil
+	}
+	if !skipConfirm && !ui.ConfirmPrompt("Apply these changes") {
+		ui.PrintInfo("Aborted.")
+		return nil
+	}
+
+	if _, err := pkgcatalog.UpdatePermissions(ctx, w, securableType, fullName, changes); err != nil {
+		return fmt.Errorf("failed to update permissions: %w", err)
+	}
+	ui.PrintSuccess("Permissions updated.")
+	return nil
+}
+
+// loadAndPlanManifest loads a manifest, plans it against the live state and
+// prints the per-securable diff and a summary.
+func loadAndPlanManifest(ctx context.Context, w *databricks.WorkspaceClient, path string) ([]pkgcatalog.SecurablePlan, error) {
+	m, err := pkgcatalog.LoadManifest(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ui.PrintInfo(fmt.Sprintf("Comparing %d securables against live grants...", len(m.Securables)))
+	plans, err := pkgcatalog.PlanManifest(ctx, w, m)
+	if err != nil {
+		return nil, err
+	}
+
+	adds, removes := 0, 0
+	for _, p := range plans {
+		ui.PrintHeader(fmt.Sprintf("%s (%s)", p.Securable.FullName, p.Securable.Type))
+		printPermissionDiffs(pkgcatalog.DiffPermissions(p.Live, p.Changes))
+		for _, c := range p.Changes {
+			adds += len(c.Add)
+			removes += len(c.Remove)
+		}
+	}
+	if len(plans) > 0 {
+		ui.PrintInfo(fmt.Sprintf("Plan: %d to grant, %d to revoke across %d securables.", adds, removes, len(plans)))
+	}
+	return plans, nil
+}
+
+func printDirectGrants(assignments []catalog.PrivilegeAssignment) {
+	var rows [][]string
+	for _, p := range assignments {
+		for _, priv := range p.Privileges {
+			rows = append(rows, []string{p.Principal, string(priv)})
+		}
+	}
+	ui.PrintTable([]string{"Principal", "Privilege"}, rows)
+}
+
+func printPermissionDiffs(diffs []pkgcatalog.PermissionDiff) {
+	for _, d := range diffs {
+		msg := fmt.Sprintf("%s %s", d.Principal, d.Privilege)
+		if d.Kind == pkgcatalog.PermissionUnchanged {
+			msg += " (no change)"
+		}
+		ui.PrintDiffLine(string(d.Kind), msg)
+	}
+}
@@ -4,9 +4,17 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"dbx-explore/pkg/auth"
 	pkgcatalog "dbx-explore/pkg/catalog"
+	"dbx-explore/pkg/files"
+	"dbx-explore/pkg/query"
 	"dbx-explore/pkg/ui"
 
 	"github.com/databricks/databricks-sdk-go"
@@ -44,8 +52,7 @@ func startInteractiveJourney() {
 			menuItems = append(menuItems, "🏗️ Infrastructure")
 			menuItems = append(menuItems, "🔄 Reset Credentials / Login")
 			// Show current warehouse if selected, or option to select
-			warehouseID := os.Getenv("DATABRICKS_WAREHOUSE_ID")
-			if warehouseID != "" {
+			if configuredWarehouseID(false) != "" {
 				menuItems = append(menuItems, "🏭 Switch SQL Warehouse")
 			} else {
 				menuItems = append(menuItems, "🏭 Select SQL Warehouse")
@@ -129,7 +136,7 @@ func selectWarehouse() {
 	var items []string
 	var ids []string
 
-	currentID := os.Getenv("DATABRICKS_WAREHOUSE_ID")
+	currentID := configuredWarehouseID(false)
 
 	for _, wh := range warehouses {
 		state := wh.State
@@ -194,28 +201,157 @@ func runCatalogLoop() {
 		}
 
 		if len(catalogs) == 0 {
-			ui.PrintError("No catalogs found.")
-			return
+			ui.PrintInfo("No catalogs found.")
 		}
 
-		catalogNames := make([]string, len(catalogs)+1)
+		catalogNames := make([]string, len(catalogs)+2)
 		for i, c := range catalogs {
 			catalogNames[i] = c.Name
 		}
+		catalogNames[len(catalogs)] = "➕ Create Catalog"
 		// Change "Exit" to "Back to Main Menu"
-		catalogNames[len(catalogs)] = "⬅️  Back to Main Menu"
+		catalogNames[len(catalogs)+1] = "⬅️  Back to Main Menu"
 
-		_, selectedCatalog, err := ui.SelectPrompt("Select Catalog", catalogNames)
+		idx, selectedCatalog, err := ui.SelectPrompt("Select Catalog", catalogNames)
 		if err != nil {
 			return
 		}
-		if selectedCatalog == "⬅️  Back to Main Menu" {
+		if idx == len(catalogs)+1 {
 			return
 		}
+		if idx == len(catalogs) {
+			createCatalogWizard(ctx, w)
+			continue
+		}
 		ui.PrintSuccess(fmt.Sprintf("Selected Catalog: %s", selectedCatalog))
 
-		// Loop for Schema Selection
-		navigateSchemas(ctx, w, selectedCatalog)
+		navigateCatalogActions(ctx, w, selectedCatalog)
+	}
+}
+
+func navigateCatalogActions(ctx context.Context, w *databricks.WorkspaceClient, catalogName string) {
+	for {
+		ui.PrintHeader(fmt.Sprintf("Catalog: %s", catalogName))
+		info, err := pkgcatalog.GetCatalog(ctx, w, catalogName)
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to get catalog details: %v", err))
+			return
+		}
+
+		actions := []string{
+			"📂 Browse Schemas",
+			"ℹ️  Catalog Details",
+			"🏷  Tags",
+			"🛡️ View Permissions",
+			"🔐 Manage Grants",
+		}
+		// Only isolated catalogs are limited to their bound workspaces.
+		if info.IsolationMode == catalog.CatalogIsolationModeIsolated {
+			actions = append(actions, "🔗 Workspace Bindings")
+		}
+		actions = append(actions, "✏️ Rename Catalog", "🗑️  Drop Catalog", "⬅️  Back to Catalogs")
+
+		_, choice, err := ui.SelectPrompt("Choose Action", actions)
+		if err != nil {
+			return
+		}
+
+		switch choice {
+		case "📂 Browse Schemas":
+			navigateSchemas(ctx, w, catalogName)
+		case "ℹ️  Catalog Details":
+			printCatalogDescription(ctx, w, info)
+			fmt.Println("\nPress Enter to continue...")
+			fmt.Scanln()
+		case "🏷  Tags":
+			manageTags(ctx, w, "catalogs", catalogName)
+		case "🛡️ View Permissions":
+			showPermissions(ctx, w, pkgcatalog.KindCatalog, catalogName)
+		case "🔐 Manage Grants":
+			manageGrants(ctx, w, pkgcatalog.KindCatalog, catalogName)
+		case "🔗 Workspace Bindings":
+			manageWorkspaceBindings(ctx, w, catalogName)
+		case "✏️ Rename Catalog":
+			if renameWizard(ctx, w, pkgcatalog.KindCatalog, catalogName) {
+				return
+			}
+		case "🗑️  Drop Catalog":
+			if dropWizard(ctx, w, pkgcatalog.KindCatalog, catalogName) {
+				return
+			}
+		case "⬅️  Back to Catalogs":
+			return
+		}
+	}
+}
+
+// manageWorkspaceBindings lists the workspaces an isolated catalog is bound to
+// and binds or unbinds workspaces.
+func manageWorkspaceBindings(ctx context.Context, w *databricks.WorkspaceClient, catalogName string) {
+	for {
+		ui.PrintHeader(fmt.Sprintf("Workspace Bindings: %s", catalogName))
+		bindings, err := printWorkspaceBindings(ctx, w, catalogName)
+		if err != nil {
+			ui.PrintError(err.Error())
+			return
+		}
+
+		actions := []string{"➕ Bind Workspace"}
+		if len(bindings) > 0 {
+			actions = append(actions, "➖ Unbind Workspace")
+		}
+		_, choice, err := ui.SelectPrompt("Manage Bindings", append(actions, "⬅️  Back"))
+		if err != nil || choice == "⬅️  Back" {
+			return
+		}
+		current, _ := w.CurrentWorkspaceID(ctx)
+
+		switch choice {
+		case "➕ Bind Workspace":
+			defaultID := ""
+			if current != 0 {
+				defaultID = strconv.FormatInt(current, 10)
+			}
+			input, err := ui.InputPrompt("Workspace ID", defaultID)
+			if err != nil || input == "" {
+				continue
+			}
+			workspaceID, err := strconv.ParseInt(input, 10, 64)
+			if err != nil {
+				ui.PrintError(fmt.Sprintf("Invalid workspace ID %q.", input))
+				continue
+			}
+			_, access, err := ui.SelectPrompt("Access", []string{"Read/Write", "Read Only"})
+			if err != nil {
+				continue
+			}
+			if err := pkgcatalog.BindWorkspace(ctx, w, catalogName, workspaceID, access == "Read Only"); err != nil {
+				ui.PrintError(err.Error())
+			} else {
+				ui.PrintSuccess(fmt.Sprintf("Bound %s to workspace %d (%s)", catalogName, workspaceID, access))
+			}
+		case "➖ Unbind Workspace":
+			items := make([]string, len(bindings))
+			for i, b := range bindings {
+				items[i] = fmt.Sprintf("%d (%s)", b.WorkspaceId, pkgcatalog.BindingLabel(b.BindingType))
+			}
+			idx, _, err := ui.SelectPrompt("Unbind Workspace", items)
+			if err != nil {
+				continue
+			}
+			b := bindings[idx]
+			if b.WorkspaceId == current {
+				ui.PrintInfo("This is the current workspace: the catalog will no longer be accessible here.")
+			}
+			if !ui.ConfirmPrompt(fmt.Sprintf("Unbind %s from workspace %d", catalogName, b.WorkspaceId)) {
+				continue
+			}
+			if err := pkgcatalog.UnbindWorkspace(ctx, w, catalogName, b); err != nil {
+				ui.PrintError(err.Error())
+			} else {
+				ui.PrintSuccess(fmt.Sprintf("Unbound %s from workspace %d", catalogName, b.WorkspaceId))
+			}
+		}
 	}
 }
 
@@ -235,19 +371,24 @@ func navigateSchemas(ctx context.Context, w *databricks.WorkspaceClient, catalog
 			schemas = append(schemas, s)
 		}
 
-		schemaNames := make([]string, len(schemas)+1)
+		schemaNames := make([]string, len(schemas)+2)
 		for i, s := range schemas {
 			schemaNames[i] = s.Name
 		}
-		schemaNames[len(schemas)] = "⬅️  Back"
+		schemaNames[len(schemas)] = "➕ Create Schema"
+		schemaNames[len(schemas)+1] = "⬅️  Back"
 
-		_, selectedSchema, err := ui.SelectPrompt("Select Schema", schemaNames)
+		idx, selectedSchema, err := ui.SelectPrompt("Select Schema", schemaNames)
 		if err != nil {
 			return
 		}
-		if selectedSchema == "⬅️  Back" {
+		if idx == len(schemas)+1 {
 			return
 		}
+		if idx == len(schemas) {
+			createSchemaWizard(ctx, w, catalogName)
+			continue
+		}
 		ui.PrintSuccess(fmt.Sprintf("Selected Schema: %s", selectedSchema))
 
 		// Loop for Object Selection
@@ -262,6 +403,12 @@ func navigateObjects(ctx context.Context, w *databricks.WorkspaceClient, catalog
 			"📦 Volumes",
 			"𝑓  Functions",
 			"🤖 Models (Registered)",
+			"ℹ️  Schema Details",
+			"🏷  Schema Tags",
+			"🛡️ View Permissions",
+			"🔐 Manage Grants",
+			"✏️ Rename Schema",
+			"🗑️  Drop Schema",
 			"⬅️  Back to Schemas",
 		}
 
@@ -279,6 +426,29 @@ func navigateObjects(ctx context.Context, w *databricks.WorkspaceClient, catalog
 			navigateFunctions(ctx, w, catalogName, schemaName)
 		case "🤖 Models (Registered)":
 			navigateModels(ctx, w, catalogName, schemaName)
+		case "ℹ️  Schema Details":
+			info, err := pkgcatalog.GetSchema(ctx, w, catalogName+"."+schemaName)
+			if err != nil {
+				ui.PrintError(fmt.Sprintf("Failed to get schema details: %v", err))
+				continue
+			}
+			printSchemaDescription(info)
+			fmt.Println("\nPress Enter to continue...")
+			fmt.Scanln()
+		case "🏷  Schema Tags":
+			manageTags(ctx, w, "schemas", catalogName+"."+schemaName)
+		case "🛡️ View Permissions":
+			showPermissions(ctx, w, pkgcatalog.KindSchema, catalogName+"."+schemaName)
+		case "🔐 Manage Grants":
+			manageGrants(ctx, w, pkgcatalog.KindSchema, catalogName+"."+schemaName)
+		case "✏️ Rename Schema":
+			if renameWizard(ctx, w, pkgcatalog.KindSchema, catalogName+"."+schemaName) {
+				return
+			}
+		case "🗑️  Drop Schema":
+			if dropWizard(ctx, w, pkgcatalog.KindSchema, catalogName+"."+schemaName) {
+				return
+			}
 		case "⬅️  Back to Schemas":
 			return
 		}
@@ -301,19 +471,24 @@ func navigateTables(ctx context.Context, w *databricks.WorkspaceClient, catalogN
 			tables = append(tables, t)
 		}
 
-		tableNames := make([]string, len(tables)+1)
+		tableNames := make([]string, len(tables)+2)
 		for i, t := range tables {
 			tableNames[i] = t.Name
 		}
-		tableNames[len(tables)] = "⬅️  Back"
+		tableNames[len(tables)] = "📏 Schema Stats"
+		tableNames[len(tables)+1] = "⬅️  Back"
 
-		_, selectedTable, err := ui.SelectPrompt("Select Table", tableNames)
+		idx, selectedTable, err := ui.SelectPrompt("Select Table", tableNames)
 		if err != nil {
 			return
 		}
-		if selectedTable == "⬅️  Back" {
+		if idx == len(tables)+1 {
 			return
 		}
+		if idx == len(tables) {
+			showSchemaStats(ctx, w, tables)
+			continue
+		}
 		ui.PrintSuccess(fmt.Sprintf("Selected Table: %s", selectedTable))
 
 		// 4. Action Menu
@@ -328,8 +503,16 @@ func navigateTableActions(ctx context.Context, w *databricks.WorkspaceClient, ca
 		actions := []string{
 			"📋 View Columns",
 			"ℹ️  Extended Metadata",
-			"📊 Sample Data (Limit 5)",
+			"📊 Sample Data",
+			"⏪ Sample Data (Time Travel)",
+			"📈 Profile",
+			"📏 Table Stats",
+			"🕰  History",
+			"🏷  Tags",
+			"✏️ Edit Comments",
 			"🛡️ View Permissions",
+			"🔐 Manage Grants",
+			"🗑️  Drop Table",
 			"⬅️  Back to Tables",
 		}
 
@@ -343,10 +526,36 @@ func navigateTableActions(ctx context.Context, w *databricks.WorkspaceClient, ca
 			showColumns(ctx, w, catalogName, schemaName, tableName)
 		case "ℹ️  Extended Metadata":
 			showExtendedMetadata(ctx, w, catalogName, schemaName, tableName)
-		case "📊 Sample Data (Limit 5)":
-			sampleData(ctx, catalogName, schemaName, tableName)
+		case "📊 Sample Data":
+			sampleDataMenu(ctx, w, catalogName, schemaName, tableName)
+		case "⏪ Sample Data (Time Travel)":
+			if asOf, ok := promptAsOf(); ok {
+				sampleData(ctx, catalogName, schemaName, tableName, asOf)
+			}
+		case "📈 Profile":
+			profileTable(ctx, w, catalogName, schemaName, tableName)
+		case "📏 Table Stats":
+			showTableStats(ctx, w, fmt.Sprintf("%s.%s.%s", catalogName, schemaName, tableName))
+		case "🕰  History":
+			showHistory(ctx, w, catalogName, schemaName, tableName)
+			continue
+		case "🏷  Tags":
+			showTableTags(ctx, w, fmt.Sprintf("%s.%s.%s", catalogName, schemaName, tableName))
+			continue
+		case "✏️ Edit Comments":
+			editComments(ctx, w, fmt.Sprintf("%s.%s.%s", catalogName, schemaName, tableName))
+			continue
 		case "🛡️ View Permissions":
-			showPermissions(ctx, w, "TABLE", fmt.Sprintf("%s.%s.%s", catalogName, schemaName, tableName))
+			showPermissions(ctx, w, pkgcatalog.KindTable, fmt.Sprintf("%s.%s.%s", catalogName, schemaName, tableName))
+			continue
+		case "🔐 Manage Grants":
+			manageGrants(ctx, w, pkgcatalog.KindTable, fmt.Sprintf("%s.%s.%s", catalogName, schemaName, tableName))
+			continue
+		case "🗑️  Drop Table":
+			if dropWizard(ctx, w, pkgcatalog.KindTable, fmt.Sprintf("%s.%s.%s", catalogName, schemaName, tableName)) {
+				return
+			}
+			continue
 		case "⬅️  Back to Tables":
 			return
 		}
@@ -375,219 +584,578 @@ func showColumns(ctx context.Context, w *databricks.WorkspaceClient, c, s, t str
 }
 
 func showExtendedMetadata(ctx context.Context, w *databricks.WorkspaceClient, c, s, t string) {
-	tableInfo, err := w.Tables.Get(ctx, catalog.GetTableRequest{FullName: fmt.Sprintf("%s.%s.%s", c, s, t)})
+	tableInfo, err := pkgcatalog.GetTable(ctx, w, fmt.Sprintf("%s.%s.%s", c, s, t))
 	if err != nil {
 		ui.PrintError(fmt.Sprintf("Failed to get table details: %v", err))
 		return
 	}
 
-	data := map[string]string{
-		"ID":               tableInfo.TableId,
-		"Type":             fmt.Sprintf("%v", tableInfo.TableType),
-		"Owner":            tableInfo.Owner,
-		"Created By":       tableInfo.CreatedBy,
-		"Storage Location": tableInfo.StorageLocation,
-		"Format":           fmt.Sprintf("%v", tableInfo.DataSourceFormat),
+	d := pkgcatalog.DescribeTable(*tableInfo)
+	printTableDescription(w, d)
+	if len(d.ForeignKeys) == 0 {
+		return
 	}
 
-	ui.PrintKeyValue("Extended Metadata", data)
+	// Foreign key targets can be opened directly.
+	items := make([]string, 0, len(d.ForeignKeys)+1)
+	for _, fk := range d.ForeignKeys {
+		items = append(items, "🔗 "+fk.ParentTable)
+	}
+	items = append(items, "⬅️  Back")
+	idx, choice, err := ui.SelectPrompt("Open Referenced Table", items)
+	if err != nil || choice == "⬅️  Back" {
+		return
+	}
+	parts := strings.SplitN(d.ForeignKeys[idx].ParentTable, ".", 3)
+	if len(parts) != 3 {
+		ui.PrintError(fmt.Sprintf("Cannot open %s.", d.ForeignKeys[idx].ParentTable))
+		return
+	}
+	navigateTableActions(ctx, w, parts[0], parts[1], parts[2])
 }
 
-func sampleData(ctx context.Context, c, s, t string) {
-	ui.PrintInfo("Querying data (via Statement Execution)...")
+// sampleSettingsFile remembers the last sample settings per table.
+const sampleSettingsFile = ".sample-settings.json"
 
-	warehouseID := os.Getenv("DATABRICKS_WAREHOUSE_ID")
-	if warehouseID == "" {
-		ui.PrintError("DATABRICKS_WAREHOUSE_ID is missing.")
-		ui.PrintInfo("Prompting for selection...")
-		selectWarehouse()
-		// Retry fetch
-		warehouseID = os.Getenv("DATABRICKS_WAREHOUSE_ID")
-		if warehouseID == "" {
-			ui.PrintError("No warehouse selected. Aborting query.")
-			return
-		}
+// sampleData samples a table with its remembered settings. asOf is an optional
+// time-travel clause such as "VERSION AS OF 3".
+func sampleData(ctx context.Context, c, s, t, asOf string) {
+	fullName := fmt.Sprintf("%s.%s.%s", c, s, t)
+	spec := query.DefaultSampleSpec()
+	if store, err := query.LoadSampleStore(sampleSettingsFile); err == nil {
+		spec = store.Get(fullName)
+	} else {
+		ui.PrintError(err.Error())
 	}
 
-	w := getWorkspaceClient()
-
-	// Fetch warehouse details to show status
-	whInfo, err := w.Warehouses.Get(ctx, sql2.GetWarehouseRequest{Id: warehouseID})
+	statement, err := spec.SQL(fullName, asOf)
 	if err != nil {
-		ui.PrintInfo(fmt.Sprintf("Warehouse ID: %s (Status: Unknown - %v)", warehouseID, err))
-	} else {
-		statusIcon := "❓"
-		switch whInfo.State {
-		case sql2.StateRunning:
-			statusIcon = "✅"
-		case sql2.StateStarting:
-			statusIcon = "⏳"
-		case sql2.StateStopped:
-			statusIcon = "🛑"
-		}
-		ui.PrintInfo(fmt.Sprintf("Using Warehouse: %s %s (%s)", statusIcon, whInfo.Name, whInfo.State))
+		ui.PrintError(err.Error())
+		return
 	}
 
-	query := fmt.Sprintf("SELECT * FROM %s.%s.%s LIMIT 5", c, s, t)
-	ui.PrintInfo(fmt.Sprintf("Executing: %s", query))
+	ui.PrintInfo("Querying data (via Statement Execution)...")
+	w := getWorkspaceClient()
+	warehouseID := requireWarehouse(ctx, w)
+	if warehouseID == "" {
+		return
+	}
+	ui.PrintInfo(fmt.Sprintf("Executing: %s", statement))
 
-	resp, err := w.StatementExecution.ExecuteAndWait(ctx, sql2.ExecuteStatementRequest{
-		WarehouseId: warehouseID,
-		Catalog:     c,
-		Schema:      s,
-		Statement:   query,
-	})
+	res, err := query.Execute(ctx, w, warehouseID, statement)
 	if err != nil {
-		ui.PrintError(fmt.Sprintf("Query failed: %v", err))
+		ui.PrintError(err.Error())
 		return
 	}
-
-	// Result handling
-	if resp.Result == nil || resp.Result.DataArray == nil {
+	if len(res.Rows) == 0 {
 		ui.PrintInfo("No data found.")
 		return
 	}
+	ui.PrintTable(res.Columns, res.Rows)
+}
 
-	// Process columns
-	var headers []string
-	if resp.Manifest != nil && resp.Manifest.Schema != nil {
-		for _, col := range resp.Manifest.Schema.Columns {
-			headers = append(headers, col.Name)
-		}
-	} else {
-		// Fallback or error?
-		ui.PrintError("No schema manifest in response.")
+// sampleDataMenu runs the remembered sample for a table or lets the user
+// change it first.
+func sampleDataMenu(ctx context.Context, w *databricks.WorkspaceClient, c, s, t string) {
+	fullName := fmt.Sprintf("%s.%s.%s", c, s, t)
+	store, err := query.LoadSampleStore(sampleSettingsFile)
+	if err != nil {
+		ui.PrintError(err.Error())
 		return
 	}
 
-	// Process rows
-	// DataArray is [][]string
-	ui.PrintTable(headers, resp.Result.DataArray)
-}
-
-func navigateFederation() {
-	ctx := context.Background()
-	w := getWorkspaceClient()
-
-	for {
-		ui.PrintHeader("Federation (Connections)")
-		conns, err := pkgcatalog.ListConnections(ctx, w)
-		if err != nil {
-			ui.PrintError(fmt.Sprintf("Failed to list connections: %v", err))
+	spec := store.Get(fullName)
+	run := fmt.Sprintf("▶️  Run (%s)", spec.Summary())
+	_, choice, err := ui.SelectPrompt("Sample Data", []string{run, "🛠️  Change Sample Settings", "⬅️  Back"})
+	if err != nil || choice == "⬅️  Back" {
+		return
+	}
+	if choice == "🛠️  Change Sample Settings" {
+		updated, ok := buildSampleSpec(ctx, w, fullName, spec)
+		if !ok {
 			return
 		}
-
-		if len(conns) == 0 {
-			ui.PrintInfo("No connections found.")
-			return
+		if err := store.Set(fullName, updated); err != nil {
+			ui.PrintError(err.Error())
 		}
+	}
+	sampleData(ctx, c, s, t, "")
+}
 
-		items := make([]string, len(conns)+1)
-		for i, c := range conns {
-			items[i] = fmt.Sprintf("%s (%s)", c.Name, c.ConnectionType)
-		}
-		items[len(conns)] = "⬅️  Back to Main Menu"
+// buildSampleSpec prompts for columns, limit, filter, ordering and sampling,
+// starting from the current settings.
+func buildSampleSpec(ctx context.Context, w *databricks.WorkspaceClient, fullName string, spec query.SampleSpec) (query.SampleSpec, bool) {
+	tableInfo, err := pkgcatalog.GetTable(ctx, w, fullName)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to get table details: %v", err))
+		return spec, false
+	}
+	columns := make([]string, len(tableInfo.Columns))
+	for i, col := range tableInfo.Columns {
+		columns[i] = col.Name
+	}
 
-		idx, choice, err := ui.SelectPrompt("Select Connection", items)
-		if err != nil || choice == "⬅️  Back to Main Menu" {
-			return
-		}
+	spec.Columns, err = ui.MultiSelectPromptWithSelection("Columns (none selected = all)", columns, spec.Columns)
+	if err != nil {
+		return spec, false
+	}
 
-		selected := conns[idx]
-		ui.PrintKeyValue("Connection Details", map[string]string{
-			"Name":      selected.Name,
-			"Type":      string(selected.ConnectionType),
-			"Owner":     selected.Owner,
-			"Created":   fmt.Sprintf("%d", selected.CreatedAt),
-			"Comment":   selected.Comment,
-			"Url":       selected.Url,
-			"Metastore": selected.MetastoreId,
-		})
-		fmt.Println("\nPress Enter to continue...")
-		fmt.Scanln()
+	limit, err := ui.InputPrompt("Row limit", fmt.Sprintf("%d", spec.Limit))
+	if err != nil {
+		return spec, false
+	}
+	if _, err := fmt.Sscanf(limit, "%d", &spec.Limit); err != nil {
+		ui.PrintError(fmt.Sprintf("Invalid limit %q.", limit))
+		return spec, false
 	}
-}
 
-func navigateInfrastructure() {
-	ctx := context.Background()
-	w := getWorkspaceClient()
+	where, err := ui.InputPrompt("WHERE predicate ('-' for none)", valueOrDash(spec.Where))
+	if err != nil {
+		return spec, false
+	}
+	spec.Where = strings.TrimSpace(where)
+	if spec.Where == "-" {
+		spec.Where = ""
+	}
 
-	for {
-		items := []string{
-			"🏗️ Metastore Summary",
-			"📦 External Locations",
-			"🔑 Storage Credentials",
-			"⬅️  Back to Main Menu",
+	_, order, err := ui.SelectPrompt("ORDER BY", append([]string{"(none)"}, columns...))
+	if err != nil {
+		return spec, false
+	}
+	spec.OrderBy, spec.Descending = "", false
+	if order != "(none)" {
+		spec.OrderBy = order
+		_, dir, err := ui.SelectPrompt("Direction", []string{"⬆️  Ascending", "⬇️  Descending"})
+		if err != nil {
+			return spec, false
 		}
+		spec.Descending = dir == "⬇️  Descending"
+	}
 
-		_, choice, err := ui.SelectPrompt("Infrastructure", items)
-		if err != nil || choice == "⬅️  Back to Main Menu" {
-			return
-		}
+	pct, err := ui.InputPrompt("TABLESAMPLE percent (0 = off)", fmt.Sprintf("%g", spec.SamplePercent))
+	if err != nil {
+		return spec, false
+	}
+	if _, err := fmt.Sscanf(pct, "%g", &spec.SamplePercent); err != nil {
+		ui.PrintError(fmt.Sprintf("Invalid percentage %q.", pct))
+		return spec, false
+	}
 
-		switch choice {
-		case "🏗️ Metastore Summary":
-			showMetastoreSummary(ctx, w)
-		case "📦 External Locations":
-			listExternalLocations(ctx, w)
-		case "🔑 Storage Credentials":
-			listStorageCredentials(ctx, w)
-		}
+	if err := spec.Validate(); err != nil {
+		ui.PrintError(err.Error())
+		return spec, false
 	}
+	return spec, true
 }
 
-func showMetastoreSummary(ctx context.Context, w *databricks.WorkspaceClient) {
-	summary, err := pkgcatalog.GetMetastoreSummary(ctx, w)
+// profileTable profiles every column of a table, optionally on a sample.
+func profileTable(ctx context.Context, w *databricks.WorkspaceClient, c, s, t string) {
+	tableInfo, err := pkgcatalog.GetTable(ctx, w, fmt.Sprintf("%s.%s.%s", c, s, t))
 	if err != nil {
-		ui.PrintError(fmt.Sprintf("Failed to get metastore summary: %v", err))
+		ui.PrintError(fmt.Sprintf("Failed to get table details: %v", err))
 		return
 	}
 
-	ui.PrintHeader("Metastore Summary")
-	ui.PrintKeyValue("Metastore Details", map[string]string{
-		"Name":           summary.Name,
-		"StorageRoot":    summary.StorageRoot,
-		"Owner":          summary.Owner,
-		"Region":         summary.Region,
-		"Cloud":          summary.Cloud,
-		"GlobalMetastoreId": summary.GlobalMetastoreId,
-		"MetastoreId":    summary.MetastoreId,
-	})
-	fmt.Println("\nPress Enter to continue...")
-	fmt.Scanln()
-}
-
-func listExternalLocations(ctx context.Context, w *databricks.WorkspaceClient) {
-	locs, err := pkgcatalog.ListExternalLocations(ctx, w)
+	pct, err := ui.InputPrompt("TABLESAMPLE percent (0 = full table)", "0")
 	if err != nil {
-		ui.PrintError(fmt.Sprintf("Failed to list external locations: %v", err))
 		return
 	}
-
-	if len(locs) == 0 {
-		ui.PrintInfo("No external locations found.")
+	var samplePercent float64
+	if _, err := fmt.Sscanf(pct, "%g", &samplePercent); err != nil {
+		ui.PrintError(fmt.Sprintf("Invalid percentage %q.", pct))
 		return
 	}
 
-	items := make([]string, len(locs)+1)
-	for i, l := range locs {
-		items[i] = l.Name
+	warehouseID := requireWarehouse(ctx, w)
+	if warehouseID == "" {
+		return
 	}
-	items[len(locs)] = "⬅️  Back"
-
-	idx, choice, err := ui.SelectPrompt("Select External Location", items)
-	if err != nil || choice == "⬅️  Back" {
+	ui.PrintInfo(fmt.Sprintf("Profiling %d columns...", len(tableInfo.Columns)))
+	p, err := query.ProfileTable(ctx, w, warehouseID, tableInfo.FullName, tableInfo.Columns, samplePercent)
+	if err != nil {
+		ui.PrintError(err.Error())
 		return
 	}
+	printTableProfile(p)
+}
 
-	selected := locs[idx]
-	ui.PrintKeyValue("External Location Details", map[string]string{
-		"Name":           selected.Name,
-		"Url":            selected.Url,
-		"Owner":          selected.Owner,
-		"Credential":     selected.CredentialName,
-		"ReadOnly":       fmt.Sprintf("%v", selected.ReadOnly),
-		"CreatedAt":      fmt.Sprintf("%d", selected.CreatedAt),
+// showTableStats prints the (cached) size statistics of one table.
+func showTableStats(ctx context.Context, w *databricks.WorkspaceClient, fullName string) {
+	warehouseID := requireWarehouse(ctx, w)
+	if warehouseID == "" {
+		return
+	}
+	stats, err := collectStats(ctx, w, warehouseID, []string{fullName}, false, 1, true)
+	if err != nil {
+		ui.PrintError(err.Error())
+		return
+	}
+	s := stats[0]
+	if s.Error != "" {
+		ui.PrintError(s.Error)
+		return
+	}
+	ui.PrintTable([]string{"Property", "Value"}, [][]string{
+		{"Format", valueOrDash(s.Format)},
+		{"Rows", fmt.Sprintf("%d", s.Rows)},
+		{"Size", fmt.Sprintf("%s (%d bytes)", ui.FormatBytes(s.SizeInBytes), s.SizeInBytes)},
+		{"Files", fmt.Sprintf("%d", s.NumFiles)},
+		{"Last Modified", valueOrDash(s.LastModified)},
+		{"Collected", s.CollectedAt.Local().Format(time.RFC1123)},
+	})
+}
+
+// showSchemaStats shows a sortable, exportable size rollup of a schema.
+func showSchemaStats(ctx context.Context, w *databricks.WorkspaceClient, tables []catalog.TableInfo) {
+	warehouseID := requireWarehouse(ctx, w)
+	if warehouseID == "" {
+		return
+	}
+	names := statsTables(tables)
+	refresh := false
+	sortKey := "size"
+	for {
+		stats, err := collectStats(ctx, w, warehouseID, names, refresh, defaultStatsConcurrency, true)
+		if err != nil {
+			ui.PrintError(err.Error())
+			return
+		}
+		refresh = false
+		_ = query.SortStats(stats, sortKey)
+		ui.PrintHeader(fmt.Sprintf("Schema Stats (by %s)", sortKey))
+		printStats(stats)
+
+		_, choice, err := ui.SelectPrompt("Stats", []string{"↕️  Sort By", "🔄 Refresh", "💾 Export", "⬅️  Back"})
+		if err != nil {
+			return
+		}
+		switch choice {
+		case "↕️  Sort By":
+			if _, key, err := ui.SelectPrompt("Sort By", query.StatsSortKeys); err == nil {
+				sortKey = key
+			}
+		case "🔄 Refresh":
+			refresh = true
+		case "💾 Export":
+			exportStats(stats)
+		case "⬅️  Back":
+			return
+		}
+	}
+}
+
+// exportStats writes stats to a CSV or JSON file chosen by the user.
+func exportStats(stats []query.TableStats) {
+	_, format, err := ui.SelectPrompt("Format", []string{"csv", "json"})
+	if err != nil {
+		return
+	}
+	path, err := ui.InputPrompt("File", "table-stats."+format)
+	if err != nil {
+		return
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to create %s: %v", path, err))
+		return
+	}
+	defer f.Close()
+	if err := writeStats(f, stats, format); err != nil {
+		ui.PrintError(err.Error())
+		return
+	}
+	ui.PrintSuccess(fmt.Sprintf("Exported %d tables to %s", len(stats), path))
+}
+
+// promptAsOf asks for a version or timestamp and returns the time-travel clause.
+func promptAsOf() (string, bool) {
+	_, choice, err := ui.SelectPrompt("Read Table As Of", []string{"🔢 Version", "📅 Timestamp", "⬅️  Back"})
+	if err != nil || choice == "⬅️  Back" {
+		return "", false
+	}
+
+	var asOf string
+	if choice == "🔢 Version" {
+		v, err := ui.InputPrompt("Version", "")
+		if err != nil {
+			return "", false
+		}
+		asOf, err = query.AsOfVersion(v)
+		if err != nil {
+			ui.PrintError(err.Error())
+			return "", false
+		}
+		return asOf, true
+	}
+
+	ts, err := ui.InputPrompt("Timestamp (YYYY-MM-DD [HH:MM:SS], UTC)", time.Now().UTC().Add(-24*time.Hour).Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return "", false
+	}
+	asOf, err = query.AsOfTimestamp(ts)
+	if err != nil {
+		ui.PrintError(err.Error())
+		return "", false
+	}
+	return asOf, true
+}
+
+// showHistory lists the Delta commits of a table and lets the user inspect a
+// version or sample it next to the current data.
+func showHistory(ctx context.Context, w *databricks.WorkspaceClient, c, s, t string) {
+	fullName := fmt.Sprintf("%s.%s.%s", c, s, t)
+	warehouseID := requireWarehouse(ctx, w)
+	if warehouseID == "" {
+		return
+	}
+
+	ui.PrintInfo(fmt.Sprintf("Running DESCRIBE HISTORY %s...", fullName))
+	history, err := query.TableHistory(ctx, w, warehouseID, fullName, 50)
+	if err != nil {
+		ui.PrintError(err.Error())
+		return
+	}
+	if len(history) == 0 {
+		ui.PrintInfo("No history found.")
+		return
+	}
+
+	for {
+		ui.PrintHeader(fmt.Sprintf("History: %s", fullName))
+		var rows [][]string
+		items := make([]string, len(history)+1)
+		for i, h := range history {
+			metrics := ui.Truncate(query.FormatMetrics(h.Metrics), ui.MaxCellWidth)
+			rows = append(rows, []string{fmt.Sprintf("%d", h.Version), h.Timestamp, h.Operation, h.User, metrics})
+			items[i] = fmt.Sprintf("Version %d: %s", h.Version, h.Operation)
+		}
+		items[len(history)] = "⬅️  Back"
+		ui.PrintTable([]string{"Version", "Timestamp", "Operation", "User", "Metrics"}, rows)
+
+		idx, choice, err := ui.SelectPrompt("Select Version", items)
+		if err != nil || choice == "⬅️  Back" {
+			return
+		}
+		showHistoryEntry(ctx, c, s, t, history[idx])
+	}
+}
+
+func showHistoryEntry(ctx context.Context, c, s, t string, h query.HistoryEntry) {
+	ui.PrintHeader(fmt.Sprintf("Version %d", h.Version))
+	ui.PrintTable([]string{"Property", "Value"}, [][]string{
+		{"Timestamp", h.Timestamp},
+		{"Operation", h.Operation},
+		{"User", h.User},
+		{"Job", valueOrDash(h.Job)},
+		{"Notebook", valueOrDash(h.Notebook)},
+		{"Engine", valueOrDash(h.EngineInfo)},
+	})
+	if len(h.Parameters) > 0 {
+		ui.PrintHeader("Operation Parameters")
+		ui.PrintTable([]string{"Parameter", "Value"}, sortedMapRows(h.Parameters))
+	}
+	if len(h.Metrics) > 0 {
+		ui.PrintHeader("Operation Metrics")
+		ui.PrintTable([]string{"Metric", "Value"}, sortedMapRows(h.Metrics))
+	}
+
+	_, choice, err := ui.SelectPrompt("Choose Action", []string{"📊 Sample This Version", "⚖️  Compare With Current", "⬅️  Back"})
+	if err != nil || choice == "⬅️  Back" {
+		return
+	}
+	asOf := fmt.Sprintf("VERSION AS OF %d", h.Version)
+	if choice == "⚖️  Compare With Current" {
+		ui.PrintHeader("Current")
+		sampleData(ctx, c, s, t, "")
+		ui.PrintHeader(fmt.Sprintf("Version %d", h.Version))
+	}
+	sampleData(ctx, c, s, t, asOf)
+	fmt.Println("\nPress Enter to continue...")
+	fmt.Scanln()
+}
+
+func sortedMapRows(m map[string]string) [][]string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	rows := make([][]string, len(keys))
+	for i, k := range keys {
+		rows[i] = []string{k, m[k]}
+	}
+	return rows
+}
+
+// configuredWarehouseID returns the SQL warehouse from DATABRICKS_WAREHOUSE_ID.
+// When required and none is set, it prints an error and exits.
+func configuredWarehouseID(required bool) string {
+	warehouseID := os.Getenv("DATABRICKS_WAREHOUSE_ID")
+	if warehouseID == "" && required {
+		ui.PrintError("DATABRICKS_WAREHOUSE_ID is missing. Run 'dbx-explore interactive' to select a warehouse.")
+		os.Exit(1)
+	}
+	return warehouseID
+}
+
+// requireWarehouse returns the configured SQL warehouse, prompting for one if
+// none is set, and prints its state. It returns "" if no warehouse was chosen.
+func requireWarehouse(ctx context.Context, w *databricks.WorkspaceClient) string {
+	warehouseID := configuredWarehouseID(false)
+	if warehouseID == "" {
+		ui.PrintError("DATABRICKS_WAREHOUSE_ID is missing.")
+		ui.PrintInfo("Prompting for selection...")
+		selectWarehouse()
+		// Retry fetch
+		warehouseID = configuredWarehouseID(false)
+		if warehouseID == "" {
+			ui.PrintError("No warehouse selected. Aborting query.")
+			return ""
+		}
+	}
+
+	// Fetch warehouse details to show status
+	whInfo, err := w.Warehouses.Get(ctx, sql2.GetWarehouseRequest{Id: warehouseID})
+	if err != nil {
+		ui.PrintInfo(fmt.Sprintf("Warehouse ID: %s (Status: Unknown - %v)", warehouseID, err))
+	} else {
+		statusIcon := "❓"
+		switch whInfo.State {
+		case sql2.StateRunning:
+			statusIcon = "✅"
+		case sql2.StateStarting:
+			statusIcon = "⏳"
+		case sql2.StateStopped:
+			statusIcon = "🛑"
+		}
+		ui.PrintInfo(fmt.Sprintf("Using Warehouse: %s %s (%s)", statusIcon, whInfo.Name, whInfo.State))
+	}
+	return warehouseID
+}
+
+func navigateFederation() {
+	ctx := context.Background()
+	w := getWorkspaceClient()
+
+	for {
+		ui.PrintHeader("Federation (Connections)")
+		conns, err := pkgcatalog.ListConnections(ctx, w)
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to list connections: %v", err))
+			return
+		}
+
+		if len(conns) == 0 {
+			ui.PrintInfo("No connections found.")
+			return
+		}
+
+		items := make([]string, len(conns)+1)
+		for i, c := range conns {
+			items[i] = fmt.Sprintf("%s (%s)", c.Name, c.ConnectionType)
+		}
+		items[len(conns)] = "⬅️  Back to Main Menu"
+
+		idx, choice, err := ui.SelectPrompt("Select Connection", items)
+		if err != nil || choice == "⬅️  Back to Main Menu" {
+			return
+		}
+
+		selected := conns[idx]
+		ui.PrintKeyValue("Connection Details", map[string]string{
+			"Name":      selected.Name,
+			"Type":      string(selected.ConnectionType),
+			"Owner":     selected.Owner,
+			"Created":   fmt.Sprintf("%d", selected.CreatedAt),
+			"Comment":   selected.Comment,
+			"Url":       selected.Url,
+			"Metastore": selected.MetastoreId,
+		})
+		fmt.Println("\nPress Enter to continue...")
+		fmt.Scanln()
+	}
+}
+
+func navigateInfrastructure() {
+	ctx := context.Background()
+	w := getWorkspaceClient()
+
+	for {
+		items := []string{
+			"🏗️ Metastore Summary",
+			"📦 External Locations",
+			"🔑 Storage Credentials",
+			"⬅️  Back to Main Menu",
+		}
+
+		_, choice, err := ui.SelectPrompt("Infrastructure", items)
+		if err != nil || choice == "⬅️  Back to Main Menu" {
+			return
+		}
+
+		switch choice {
+		case "🏗️ Metastore Summary":
+			showMetastoreSummary(ctx, w)
+		case "📦 External Locations":
+			listExternalLocations(ctx, w)
+		case "🔑 Storage Credentials":
+			listStorageCredentials(ctx, w)
+		}
+	}
+}
+
+func showMetastoreSummary(ctx context.Context, w *databricks.WorkspaceClient) {
+	summary, err := pkgcatalog.GetMetastoreSummary(ctx, w)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to get metastore summary: %v", err))
+		return
+	}
+
+	ui.PrintHeader("Metastore Summary")
+	ui.PrintKeyValue("Metastore Details", map[string]string{
+		"Name":           summary.Name,
+		"StorageRoot":    summary.StorageRoot,
+		"Owner":          summary.Owner,
+		"Region":         summary.Region,
+		"Cloud":          summary.Cloud,
+		"GlobalMetastoreId": summary.GlobalMetastoreId,
+		"MetastoreId":    summary.MetastoreId,
+	})
+	fmt.Println("\nPress Enter to continue...")
+	fmt.Scanln()
+}
+
+func listExternalLocations(ctx context.Context, w *databricks.WorkspaceClient) {
+	locs, err := pkgcatalog.ListExternalLocations(ctx, w)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to list external locations: %v", err))
+		return
+	}
+
+	if len(locs) == 0 {
+		ui.PrintInfo("No external locations found.")
+		return
+	}
+
+	items := make([]string, len(locs)+1)
+	for i, l := range locs {
+		items[i] = l.Name
+	}
+	items[len(locs)] = "⬅️  Back"
+
+	idx, choice, err := ui.SelectPrompt("Select External Location", items)
+	if err != nil || choice == "⬅️  Back" {
+		return
+	}
+
+	selected := locs[idx]
+	ui.PrintKeyValue("External Location Details", map[string]string{
+		"Name":           selected.Name,
+		"Url":            selected.Url,
+		"Owner":          selected.Owner,
+		"Credential":     selected.CredentialName,
+		"ReadOnly":       fmt.Sprintf("%v", selected.ReadOnly),
+		"CreatedAt":      fmt.Sprintf("%d", selected.CreatedAt),
 	})
 	fmt.Println("\nPress Enter to continue...")
 	fmt.Scanln()
@@ -626,22 +1194,254 @@ func listStorageCredentials(ctx context.Context, w *databricks.WorkspaceClient)
 	fmt.Scanln()
 }
 
-func showPermissions(ctx context.Context, w *databricks.WorkspaceClient, securableType, fullName string) {
-	ui.PrintInfo(fmt.Sprintf("Fetching permissions for %s (%s)...", fullName, securableType))
-	perms, err := pkgcatalog.GetEffectivePermissions(ctx, w, securableType, fullName)
-	if err != nil {
-		ui.PrintError(fmt.Sprintf("Failed to get permissions: %v", err))
+func showPermissions(ctx context.Context, w *databricks.WorkspaceClient, kind pkgcatalog.ObjectKind, fullName string) {
+	securableType := string(pkgcatalog.SecurableTypeFor(kind))
+	effective := true
+	for {
+		if effective {
+			ui.PrintHeader(fmt.Sprintf("Effective Permissions: %s", fullName))
+			ui.PrintInfo(fmt.Sprintf("Fetching permissions for %s (%s)...", fullName, securableType))
+			if err := printEffectivePermissions(ctx, w, securableType, fullName); err != nil {
+				ui.PrintError(err.Error())
+				return
+			}
+		} else {
+			ui.PrintHeader(fmt.Sprintf("Direct Grants: %s", fullName))
+			perms, err := pkgcatalog.GetPermissions(ctx, w, securableType, fullName)
+			if err != nil {
+				ui.PrintError(fmt.Sprintf("Failed to get permissions: %v", err))
+				return
+			}
+			printDirectGrants(perms.PrivilegeAssignments)
+		}
+
+		toggle := "🔁 Show Direct Grants"
+		if !effective {
+			toggle = "🔁 Show Effective Permissions"
+		}
+		_, choice, err := ui.SelectPrompt("Permissions", []string{toggle, "⬅️  Back"})
+		if err != nil || choice == "⬅️  Back" {
+			return
+		}
+		effective = !effective
+	}
+}
+
+// editComments edits the table comment and column comments, with a walk
+// through the columns that have none.
+func editComments(ctx context.Context, w *databricks.WorkspaceClient, fullName string) {
+	warehouseID := requireWarehouse(ctx, w)
+	if warehouseID == "" {
 		return
 	}
+	for {
+		tableInfo, err := pkgcatalog.GetTable(ctx, w, fullName)
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to get table details: %v", err))
+			return
+		}
+		var undocumented []catalog.ColumnInfo
+		for _, col := range tableInfo.Columns {
+			if col.Comment == "" {
+				undocumented = append(undocumented, col)
+			}
+		}
 
-	if len(perms.PrivilegeAssignments) == 0 {
-		ui.PrintInfo("No permissions found.")
-		fmt.Println("\nPress Enter to continue...")
-		fmt.Scanln()
+		ui.PrintHeader(fmt.Sprintf("Comments: %s", fullName))
+		fmt.Printf("Table comment: %s\n", valueOrDash(tableInfo.Comment))
+		fmt.Printf("Documented columns: %d of %d\n", len(tableInfo.Columns)-len(undocumented), len(tableInfo.Columns))
+
+		walk := fmt.Sprintf("🔍 Undocumented Columns (%d)", len(undocumented))
+		_, choice, err := ui.SelectPrompt("Edit Comments", []string{"📝 Table Comment", walk, "📋 Choose Column", "⬅️  Back"})
+		if err != nil || choice == "⬅️  Back" {
+			return
+		}
+
+		switch choice {
+		case "📝 Table Comment":
+			if text, ok := promptComment("Table comment", tableInfo.Comment); ok && text != tableInfo.Comment {
+				applyComment(ctx, w, warehouseID, fullName, "", text)
+			}
+		case walk:
+			if len(undocumented) == 0 {
+				ui.PrintSuccess("Every column has a comment.")
+				continue
+			}
+			ui.PrintInfo("Enter skips a column, :e opens $EDITOR, :q stops.")
+			for i, col := range undocumented {
+				label := fmt.Sprintf("[%d/%d] %s (%s)", i+1, len(undocumented), col.Name, col.TypeText)
+				text, ok := promptComment(label, "")
+				if !ok {
+					break
+				}
+				if text != "" {
+					applyComment(ctx, w, warehouseID, fullName, col.Name, text)
+				}
+			}
+		case "📋 Choose Column":
+			names := make([]string, len(tableInfo.Columns))
+			for i, col := range tableInfo.Columns {
+				names[i] = fmt.Sprintf("%s — %s", col.Name, valueOrDash(col.Comment))
+			}
+			idx, _, err := ui.SelectPrompt("Select Column", names)
+			if err != nil {
+				continue
+			}
+			col := tableInfo.Columns[idx]
+			if text, ok := promptComment(col.Name, col.Comment); ok && text != col.Comment {
+				applyComment(ctx, w, warehouseID, fullName, col.Name, text)
+			}
+		}
+	}
+}
+
+// promptComment asks for a comment on one line; ":e" opens $EDITOR for longer
+// text. It returns false when the user stops with ":q" or Ctrl+C.
+func promptComment(label, current string) (string, bool) {
+	text, err := ui.InputPrompt(label, current)
+	if err != nil || text == ":q" {
+		return "", false
+	}
+	if text == ":e" {
+		if text, err = ui.EditText(current); err != nil {
+			ui.PrintError(err.Error())
+			return "", false
+		}
+	}
+	return text, true
+}
+
+func applyComment(ctx context.Context, w *databricks.WorkspaceClient, warehouseID, fullName, column, comment string) {
+	if err := query.SetComment(ctx, w, warehouseID, fullName, column, comment); err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to update comment: %v", err))
 		return
 	}
+	ui.PrintSuccess(fmt.Sprintf("Updated comment on %s", commentTarget(fullName, column)))
+}
+
+// manageTags shows the tags on one object and lets the user set or remove them.
+func manageTags(ctx context.Context, w *databricks.WorkspaceClient, entityType, fullName string) {
+	for {
+		ui.PrintHeader(fmt.Sprintf("Tags: %s", fullName))
+		tags, err := pkgcatalog.ListTags(ctx, w, entityType, fullName)
+		if err != nil {
+			ui.PrintError(err.Error())
+			return
+		}
+		printTags(tags)
+
+		actions := []string{"➕ Set Tag"}
+		if len(tags) > 0 {
+			actions = append(actions, "➖ Remove Tag")
+		}
+		_, choice, err := ui.SelectPrompt("Manage Tags", append(actions, "⬅️  Back"))
+		if err != nil || choice == "⬅️  Back" {
+			return
+		}
+
+		switch choice {
+		case "➕ Set Tag":
+			input, err := ui.InputPrompt("Tag (key or key=value)", "")
+			if err != nil || input == "" {
+				continue
+			}
+			tag, err := pkgcatalog.ParseTag(input)
+			if err == nil {
+				err = pkgcatalog.SetTag(ctx, w, entityType, fullName, tag)
+			}
+			if err != nil {
+				ui.PrintError(err.Error())
+			} else {
+				ui.PrintSuccess(fmt.Sprintf("Set %s", tag))
+			}
+		case "➖ Remove Tag":
+			keys := make([]string, len(tags))
+			for i, t := range tags {
+				keys[i] = t.String()
+			}
+			idx, _, err := ui.SelectPrompt("Remove Tag", keys)
+			if err != nil {
+				continue
+			}
+			if err := pkgcatalog.UnsetTag(ctx, w, entityType, fullName, tags[idx].Key); err != nil {
+				ui.PrintError(err.Error())
+			} else {
+				ui.PrintSuccess(fmt.Sprintf("Removed %s", tags[idx].Key))
+			}
+		}
+	}
+}
+
+// showTableTags shows the tags on a table and its columns, and opens the tag
+// editor for the table or a column.
+func showTableTags(ctx context.Context, w *databricks.WorkspaceClient, fullName string) {
+	for {
+		tableInfo, err := pkgcatalog.GetTable(ctx, w, fullName)
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to get table details: %v", err))
+			return
+		}
+		columns := make([]string, len(tableInfo.Columns))
+		for i, c := range tableInfo.Columns {
+			columns[i] = c.Name
+		}
+
+		ui.PrintHeader(fmt.Sprintf("Table Tags: %s", fullName))
+		tags, err := pkgcatalog.ListTags(ctx, w, "tables", fullName)
+		if err != nil {
+			ui.PrintError(err.Error())
+			return
+		}
+		printTags(tags)
+
+		ui.PrintHeader("Column Tags")
+		columnTags, err := pkgcatalog.ListColumnTags(ctx, w, fullName, columns)
+		if err != nil {
+			ui.PrintError(err.Error())
+			return
+		}
+		var rows [][]string
+		for _, col := range columns {
+			if tags, ok := columnTags[col]; ok {
+				parts := make([]string, len(tags))
+				for i, t := range tags {
+					parts[i] = t.String()
+				}
+				rows = append(rows, []string{col, strings.Join(parts, ", ")})
+			}
+		}
+		if len(rows) == 0 {
+			ui.PrintInfo("No column tags.")
+		} else {
+			ui.PrintTable([]string{"Column", "Tags"}, rows)
+		}
+
+		_, choice, err := ui.SelectPrompt("Tags", []string{"🏷  Edit Table Tags", "🏷  Edit Column Tags", "⬅️  Back"})
+		if err != nil || choice == "⬅️  Back" {
+			return
+		}
+		if choice == "🏷  Edit Table Tags" {
+			manageTags(ctx, w, "tables", fullName)
+			continue
+		}
+		idx, _, err := ui.SelectPrompt("Select Column", columns)
+		if err != nil {
+			continue
+		}
+		manageTags(ctx, w, "columns", fullName+"."+columns[idx])
+	}
+}
+
+// printEffectivePermissions prints effective permissions, highlighting the
+// privileges inherited from a parent catalog or schema.
+func printEffectivePermissions(ctx context.Context, w *databricks.WorkspaceClient, securableType, fullName string) error {
+	perms, err := pkgcatalog.GetEffectivePermissions(ctx, w, securableType, fullName)
+	if err != nil {
+		return fmt.Errorf("failed to get permissions: %w", err)
+	}
 
 	var rows [][]string
+	var inherited []bool
 	for _, p := range perms.PrivilegeAssignments {
 		for _, priv := range p.Privileges {
 			inheritedFrom := "Direct"
@@ -649,12 +1449,58 @@ func showPermissions(ctx context.Context, w *databricks.WorkspaceClient, securab
 				inheritedFrom = fmt.Sprintf("%s (%s)", priv.InheritedFromType, priv.InheritedFromName)
 			}
 			rows = append(rows, []string{p.Principal, string(priv.Privilege), inheritedFrom})
+			inherited = append(inherited, priv.InheritedFromType != "")
 		}
 	}
 
-	ui.PrintTable([]string{"Principal", "Privilege", "Inherited From"}, rows)
-	fmt.Println("\nPress Enter to continue...")
-	fmt.Scanln()
+	ui.PrintTableWithHighlights([]string{"Principal", "Privilege", "Inherited From"}, rows, inherited)
+	return nil
+}
+
+func manageGrants(ctx context.Context, w *databricks.WorkspaceClient, kind pkgcatalog.ObjectKind, fullName string) {
+	securableType := string(pkgcatalog.SecurableTypeFor(kind))
+	for {
+		ui.PrintHeader(fmt.Sprintf("Grants: %s", fullName))
+		perms, err := pkgcatalog.GetPermissions(ctx, w, securableType, fullName)
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to get permissions: %v", err))
+			return
+		}
+
+		printDirectGrants(perms.PrivilegeAssignments)
+
+		_, choice, err := ui.SelectPrompt("Manage Grants", []string{"➕ Grant", "➖ Revoke", "⬅️  Back"})
+		if err != nil || choice == "⬅️  Back" {
+			return
+		}
+		revoke := choice == "➖ Revoke"
+
+		input, err := ui.InputPrompt("Principal(s), comma-separated", "")
+		if err != nil || input == "" {
+			continue
+		}
+		principals := strings.Split(input, ",")
+
+		var options []string
+		for _, p := range new(catalog.Privilege).Values() {
+			options = append(options, string(p))
+		}
+		picked, err := ui.MultiSelectPrompt("Select Privileges", options)
+		if err != nil || len(picked) == 0 {
+			continue
+		}
+		var privileges []catalog.Privilege
+		for _, p := range picked {
+			privileges = append(privileges, catalog.Privilege(p))
+		}
+
+		changes := pkgcatalog.BuildPermissionChanges(principals, privileges, revoke)
+		if err := applyPermissionChanges(ctx, w, securableType, fullName, changes, false, false); err != nil {
+			ui.PrintError(err.Error())
+		}
+		fmt.Println("\nPress Enter to continue...")
+		fmt.Scanln()
+	}
 }
 
 func navigateVolumes(ctx context.Context, w *databricks.WorkspaceClient, catalogName, schemaName string) {
@@ -667,55 +1513,184 @@ func navigateVolumes(ctx context.Context, w *databricks.WorkspaceClient, catalog
 
 		if len(vols) == 0 {
 			ui.PrintInfo("No volumes found.")
-			return
 		}
 
-		items := make([]string, len(vols)+1)
+		items := make([]string, len(vols)+2)
 		for i, v := range vols {
 			items[i] = fmt.Sprintf("%s (%s)", v.Name, v.VolumeType)
 		}
-		items[len(vols)] = "⬅️  Back"
+		items[len(vols)] = "➕ Create Volume"
+		items[len(vols)+1] = "⬅️  Back"
+
+		idx, choice, err := ui.SelectPrompt("Select Volume", items)
+		if err != nil || choice == "⬅️  Back" {
+			return
+		}
+		if idx == len(vols) {
+			createVolumeWizard(ctx, w, catalogName, schemaName)
+			continue
+		}
+
+		selected := vols[idx]
+		navigateVolumeActions(ctx, w, selected)
+	}
+}
+
+func navigateVolumeActions(ctx context.Context, w *databricks.WorkspaceClient, vol catalog.VolumeInfo) {
+	for {
+		ui.PrintHeader(fmt.Sprintf("Volume: %s", vol.Name))
+		actions := []string{
+			"📄 View Details",
+			"🛡️ View Permissions",
+			"📁 Browse Files",
+			"🏷  Tags",
+			"🔐 Manage Grants",
+			"✏️ Rename Volume",
+			"🗑️  Drop Volume",
+			"⬅️  Back to Volumes",
+		}
+
+		_, choice, err := ui.SelectPrompt("Choose Action", actions)
+		if err != nil {
+			return
+		}
+
+		switch choice {
+		case "📄 View Details":
+			ui.PrintKeyValue("Volume Details", map[string]string{
+				"Name":            vol.Name,
+				"Type":            string(vol.VolumeType),
+				"Owner":           vol.Owner,
+				"StorageLocation": vol.StorageLocation,
+				"Comment":         vol.Comment,
+			})
+			fmt.Println("\nPress Enter to continue...")
+			fmt.Scanln()
+		case "🛡️ View Permissions":
+			showPermissions(ctx, w, pkgcatalog.KindVolume, vol.FullName)
+		case "📁 Browse Files":
+			browseVolume(ctx, w, vol)
+		case "🏷  Tags":
+			manageTags(ctx, w, "volumes", vol.FullName)
+		case "🔐 Manage Grants":
+			manageGrants(ctx, w, pkgcatalog.KindVolume, vol.FullName)
+		case "✏️ Rename Volume":
+			if renameWizard(ctx, w, pkgcatalog.KindVolume, vol.FullName) {
+				return
+			}
+		case "🗑️  Drop Volume":
+			if dropWizard(ctx, w, pkgcatalog.KindVolume, vol.FullName) {
+				return
+			}
+		case "⬅️  Back to Volumes":
+			return
+		}
+	}
+}
+
+func browseVolume(ctx context.Context, w *databricks.WorkspaceClient, vol catalog.VolumeInfo) {
+	root := files.VolumePath(vol.CatalogName, vol.SchemaName, vol.Name)
+	dir := root
+	for {
+		ui.PrintHeader(dir)
+		entries, err := files.List(ctx, w, dir)
+		if err != nil {
+			ui.PrintError(err.Error())
+			return
+		}
+
+		var items []string
+		if dir != root {
+			items = append(items, "⬆️  ..")
+		}
+		offset := len(items)
+		for _, e := range entries {
+			if e.IsDirectory {
+				items = append(items, fmt.Sprintf("📁 %s/", e.Name))
+			} else {
+				items = append(items, fmt.Sprintf("📄 %s  (%s, %s)", e.Name, ui.FormatBytes(e.Size), e.LastModified.Format("2006-01-02 15:04")))
+			}
+		}
+		items = append(items, "⬆️  Upload File Here", "⬅️  Back")
+
+		idx, choice, err := ui.SelectPrompt("Browse", items)
+		if err != nil || choice == "⬅️  Back" {
+			return
+		}
+
+		switch choice {
+		case "⬆️  ..":
+			dir = path.Dir(dir)
+		case "⬆️  Upload File Here":
+			local, err := ui.InputPrompt("Local file to upload", "")
+			if err != nil || local == "" {
+				continue
+			}
+			progress, done := transferProgress(filepath.Base(local))
+			err = files.UploadFile(ctx, w, local, path.Join(dir, filepath.Base(local)), false, progress)
+			done()
+			if err != nil {
+				ui.PrintError(err.Error())
+			} else {
+				ui.PrintSuccess("Uploaded.")
+			}
+		default:
+			e := entries[idx-offset]
+			if e.IsDirectory {
+				dir = e.Path
+				continue
+			}
+			fileActions(ctx, w, e)
+		}
+	}
+}
 
-		idx, choice, err := ui.SelectPrompt("Select Volume", items)
-		if err != nil || choice == "⬅️  Back" {
-			return
-		}
+func fileActions(ctx context.Context, w *databricks.WorkspaceClient, e files.Entry) {
+	ui.PrintKeyValue("File Details", map[string]string{
+		"Path":     e.Path,
+		"Size":     ui.FormatBytes(e.Size),
+		"Modified": e.LastModified.Format("2006-01-02 15:04:05"),
+	})
 
-		selected := vols[idx]
-		navigateVolumeActions(ctx, w, selected)
+	_, choice, err := ui.SelectPrompt("Choose Action", []string{"👀 Preview", "⬇️  Download", "🗑️  Delete", "⬅️  Back"})
+	if err != nil {
+		return
 	}
-}
 
-func navigateVolumeActions(ctx context.Context, w *databricks.WorkspaceClient, vol catalog.VolumeInfo) {
-	for {
-		ui.PrintHeader(fmt.Sprintf("Volume: %s", vol.Name))
-		actions := []string{
-			"📄 View Details",
-			"🛡️ View Permissions",
-			"⬅️  Back to Volumes",
+	switch choice {
+	case "👀 Preview":
+		if err := previewFile(ctx, w, e, defaultPreviewBytes, defaultPreviewRows); err != nil {
+			ui.PrintError(err.Error())
 		}
-
-		_, choice, err := ui.SelectPrompt("Choose Action", actions)
+	case "⬇️  Download":
+		local, err := ui.InputPrompt("Save to", e.Name)
+		if err != nil || local == "" {
+			return
+		}
+		overwrite := false
+		if _, err := os.Stat(local); err == nil {
+			if !ui.ConfirmPrompt(fmt.Sprintf("Overwrite %s", local)) {
+				return
+			}
+			overwrite = true
+		}
+		progress, done := transferProgress(e.Name)
+		err = files.DownloadFile(ctx, w, e.Path, local, overwrite, progress)
+		done()
 		if err != nil {
+			ui.PrintError(err.Error())
 			return
 		}
-
-		switch choice {
-		case "📄 View Details":
-			ui.PrintKeyValue("Volume Details", map[string]string{
-				"Name":            vol.Name,
-				"Type":            string(vol.VolumeType),
-				"Owner":           vol.Owner,
-				"StorageLocation": vol.StorageLocation,
-				"Comment":         vol.Comment,
-			})
-			fmt.Println("\nPress Enter to continue...")
-			fmt.Scanln()
-		case "🛡️ View Permissions":
-			showPermissions(ctx, w, "VOLUME", vol.FullName)
-		case "⬅️  Back to Volumes":
+		ui.PrintSuccess(fmt.Sprintf("Saved to %s", local))
+	case "🗑️  Delete":
+		if !ui.ConfirmPrompt(fmt.Sprintf("Delete %s", e.Path)) {
+			return
+		}
+		if err := files.Delete(ctx, w, e.Path, false); err != nil {
+			ui.PrintError(err.Error())
 			return
 		}
+		ui.PrintSuccess("Deleted.")
 	}
 }
 
@@ -759,7 +1734,9 @@ func navigateFunctionActions(ctx context.Context, w *databricks.WorkspaceClient,
 		ui.PrintHeader(fmt.Sprintf("Function: %s", fn.Name))
 		actions := []string{
 			"📄 View Details",
+			"▶️  Run Function",
 			"🛡️ View Permissions",
+			"🔐 Manage Grants",
 			"⬅️  Back to Functions",
 		}
 
@@ -770,31 +1747,125 @@ func navigateFunctionActions(ctx context.Context, w *databricks.WorkspaceClient,
 
 		switch choice {
 		case "📄 View Details":
-			ui.PrintKeyValue("Function Details", map[string]string{
-				"Name":            fn.Name,
-				"DataType":        string(fn.DataType),
-				"Owner":           fn.Owner,
-				"RoutineBody":     string(fn.RoutineBody),
-				"IsDeterministic": fmt.Sprintf("%v", fn.IsDeterministic),
-				"Comment":         fn.Comment,
-			})
-
-			if fn.RoutineDefinition != "" {
-				fmt.Println("\n📜 Routine Definition:")
-				fmt.Println("--------------------------------------------------")
-				fmt.Println(fn.RoutineDefinition)
-				fmt.Println("--------------------------------------------------")
-			}
-			fmt.Println("\nPress Enter to continue...")
-			fmt.Scanln()
+			showFunctionDetails(ctx, w, fn.FullName)
+		case "▶️  Run Function":
+			runFunction(ctx, w, fn.FullName)
 		case "🛡️ View Permissions":
-			showPermissions(ctx, w, "FUNCTION", fn.FullName)
+			showPermissions(ctx, w, pkgcatalog.KindFunction, fn.FullName)
+		case "🔐 Manage Grants":
+			manageGrants(ctx, w, pkgcatalog.KindFunction, fn.FullName)
 		case "⬅️  Back to Functions":
 			return
 		}
 	}
 }
 
+func showFunctionDetails(ctx context.Context, w *databricks.WorkspaceClient, fullName string) {
+	fn, err := pkgcatalog.GetFunction(ctx, w, fullName)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to get function: %v", err))
+		return
+	}
+
+	ui.PrintKeyValue("Function Details", map[string]string{
+		"Name":            fn.Name,
+		"DataType":        string(fn.DataType),
+		"Owner":           fn.Owner,
+		"Language":        pkgcatalog.FunctionLanguage(*fn),
+		"IsDeterministic": fmt.Sprintf("%v", fn.IsDeterministic),
+		"Comment":         fn.Comment,
+	})
+
+	ui.PrintHeader("Signature")
+	fmt.Println(pkgcatalog.FunctionSignature(*fn))
+
+	if params := pkgcatalog.FunctionParams(fn.InputParams); len(params) > 0 {
+		var rows [][]string
+		for _, p := range params {
+			rows = append(rows, []string{p.Name, strings.ToUpper(p.TypeText), valueOrDash(p.ParameterDefault), valueOrDash(p.Comment)})
+		}
+		ui.PrintHeader("Parameters")
+		ui.PrintTable([]string{"Name", "Type", "Default", "Comment"}, rows)
+	}
+	if pkgcatalog.IsTableFunction(*fn) {
+		var rows [][]string
+		for _, p := range pkgcatalog.FunctionParams(fn.ReturnParams) {
+			rows = append(rows, []string{p.Name, strings.ToUpper(p.TypeText), valueOrDash(p.Comment)})
+		}
+		ui.PrintHeader("Returns Table")
+		ui.PrintTable([]string{"Column", "Type", "Comment"}, rows)
+	}
+
+	if fn.RoutineDefinition != "" {
+		fmt.Printf("\n📜 Routine Definition (%s):\n", pkgcatalog.FunctionLanguage(*fn))
+		ui.PrintCode(fn.RoutineDefinition, pkgcatalog.FunctionLanguage(*fn))
+	}
+	fmt.Println("\nPress Enter to continue...")
+	fmt.Scanln()
+}
+
+// runFunction prompts for each argument and invokes the function through the
+// Statement Execution API with named parameters.
+func runFunction(ctx context.Context, w *databricks.WorkspaceClient, fullName string) {
+	fn, err := pkgcatalog.GetFunction(ctx, w, fullName)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to get function: %v", err))
+		return
+	}
+
+	ui.PrintInfo("Enter a value for each parameter. Type NULL for a null value; complex types take JSON.")
+	var args []query.Argument
+	for _, p := range pkgcatalog.FunctionParams(fn.InputParams) {
+		label := fmt.Sprintf("%s (%s)", p.Name, strings.ToUpper(p.TypeText))
+		if p.ParameterDefault != "" {
+			label += fmt.Sprintf(" [blank = default %s]", p.ParameterDefault)
+		}
+		value, err := ui.InputPrompt(label, "")
+		if err != nil {
+			return
+		}
+		switch {
+		case value == "" && p.ParameterDefault != "":
+			continue
+		case strings.EqualFold(value, "NULL"):
+			args = append(args, query.Argument{Name: p.Name})
+		default:
+			v := value
+			args = append(args, query.Argument{Name: p.Name, Value: &v})
+		}
+	}
+
+	warehouseID := requireWarehouse(ctx, w)
+	if warehouseID == "" {
+		return
+	}
+	statement, params := query.FunctionCall(*fn, args)
+	ui.PrintInfo(fmt.Sprintf("Executing: %s", statement))
+	for _, p := range params {
+		if p.Value == "" && len(p.ForceSendFields) == 0 {
+			fmt.Printf("  :%s = NULL\n", p.Name)
+		} else {
+			fmt.Printf("  :%s = %s (%s)\n", p.Name, p.Value, p.Type)
+		}
+	}
+
+	res, err := query.Execute(ctx, w, warehouseID, statement, params...)
+	if err != nil {
+		ui.PrintError(err.Error())
+		return
+	}
+	ui.PrintTable(res.Columns, res.Rows)
+	fmt.Println("\nPress Enter to continue...")
+	fmt.Scanln()
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
 func navigateModels(ctx context.Context, w *databricks.WorkspaceClient, catalogName, schemaName string) {
 	for {
 		models, err := pkgcatalog.ListModels(ctx, w, catalogName, schemaName)
@@ -829,7 +1900,11 @@ func navigateModelActions(ctx context.Context, w *databricks.WorkspaceClient, mo
 		ui.PrintHeader(fmt.Sprintf("Model: %s", model.Name))
 		actions := []string{
 			"📄 View Details",
+			"📦 View Versions",
+			"🏷️  Manage Aliases",
+			"⚖️  Compare Versions",
 			"🛡️ View Permissions",
+			"🔐 Manage Grants",
 			"⬅️  Back to Models",
 		}
 
@@ -844,15 +1919,389 @@ func navigateModelActions(ctx context.Context, w *databricks.WorkspaceClient, mo
 				"Name":      model.Name,
 				"Owner":     model.Owner,
 				"Comment":   model.Comment,
-				"CreatedAt": fmt.Sprintf("%d", model.CreatedAt),
-				"UpdatedAt": fmt.Sprintf("%d", model.UpdatedAt),
+				"CreatedAt": formatMillis(model.CreatedAt),
+				"UpdatedAt": formatMillis(model.UpdatedAt),
 			})
 			fmt.Println("\nPress Enter to continue...")
 			fmt.Scanln()
+		case "📦 View Versions":
+			showModelVersions(ctx, w, model.FullName)
+		case "🏷️  Manage Aliases":
+			manageModelAliases(ctx, w, model.FullName)
+		case "⚖️  Compare Versions":
+			compareModelVersions(ctx, w, model.FullName)
 		case "🛡️ View Permissions":
-			showPermissions(ctx, w, "FUNCTION", model.FullName) // Models are securable type FUNCTION in SDK? Need to check.
+			showPermissions(ctx, w, pkgcatalog.KindModel, model.FullName)
+		case "🔐 Manage Grants":
+			manageGrants(ctx, w, pkgcatalog.KindModel, model.FullName)
 		case "⬅️  Back to Models":
 			return
 		}
 	}
 }
+
+// loadModelVersions fetches a model's versions together with its aliases
+// grouped by version.
+func loadModelVersions(ctx context.Context, w *databricks.WorkspaceClient, fullName string) ([]catalog.ModelVersionInfo, map[int][]string, error) {
+	model, err := pkgcatalog.GetModel(ctx, w, fullName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get model: %w", err)
+	}
+	versions, err := pkgcatalog.ListModelVersions(ctx, w, fullName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return versions, pkgcatalog.AliasesByVersion(model.Aliases), nil
+}
+
+func showModelVersions(ctx context.Context, w *databricks.WorkspaceClient, fullName string) {
+	versions, aliases, err := loadModelVersions(ctx, w, fullName)
+	if err != nil {
+		ui.PrintError(err.Error())
+		return
+	}
+	if len(versions) == 0 {
+		ui.PrintInfo("No versions found.")
+		return
+	}
+
+	for {
+		var rows [][]string
+		items := make([]string, len(versions)+1)
+		for i, v := range versions {
+			rows = append(rows, []string{
+				fmt.Sprintf("%d", v.Version),
+				string(v.Status),
+				strings.Join(aliases[v.Version], ", "),
+				v.RunId,
+				formatMillis(v.CreatedAt),
+				v.CreatedBy,
+				v.StorageLocation,
+			})
+			items[i] = fmt.Sprintf("Version %d", v.Version)
+		}
+		items[len(versions)] = "⬅️  Back"
+		ui.PrintTable([]string{"Version", "Status", "Aliases", "Source Run", "Created", "Created By", "Storage Location"}, rows)
+
+		idx, choice, err := ui.SelectPrompt("Select Version", items)
+		if err != nil || choice == "⬅️  Back" {
+			return
+		}
+
+		v, err := pkgcatalog.GetModelVersion(ctx, w, fullName, versions[idx].Version)
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to get version: %v", err))
+			continue
+		}
+		ui.PrintHeader(fmt.Sprintf("%s version %d", fullName, v.Version))
+		var details [][]string
+		for _, f := range modelVersionFields(*v, aliases[v.Version]) {
+			details = append(details, []string{f[0], f[1]})
+		}
+		ui.PrintTable([]string{"Property", "Value"}, details)
+		fmt.Println("\nPress Enter to continue...")
+		fmt.Scanln()
+	}
+}
+
+// modelVersionFields lists the metadata of a version in display order.
+func modelVersionFields(v catalog.ModelVersionInfo, aliases []string) [][2]string {
+	deps := pkgcatalog.ModelVersionDependencies(v)
+	return [][2]string{
+		{"Version", fmt.Sprintf("%d", v.Version)},
+		{"Status", string(v.Status)},
+		{"Aliases", strings.Join(aliases, ", ")},
+		{"Comment", v.Comment},
+		{"Source", v.Source},
+		{"Source Run", v.RunId},
+		{"Run Workspace", fmt.Sprintf("%d", v.RunWorkspaceId)},
+		{"Storage Location", v.StorageLocation},
+		{"Dependencies", strings.Join(deps, ", ")},
+		{"Created At", formatMillis(v.CreatedAt)},
+		{"Created By", v.CreatedBy},
+		{"Updated At", formatMillis(v.UpdatedAt)},
+		{"Updated By", v.UpdatedBy},
+	}
+}
+
+func manageModelAliases(ctx context.Context, w *databricks.WorkspaceClient, fullName string) {
+	for {
+		model, err := pkgcatalog.GetModel(ctx, w, fullName)
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to get model: %v", err))
+			return
+		}
+
+		ui.PrintHeader(fmt.Sprintf("Aliases: %s", fullName))
+		if len(model.Aliases) == 0 {
+			ui.PrintInfo("No aliases set.")
+		} else {
+			var rows [][]string
+			for _, a := range model.Aliases {
+				rows = append(rows, []string{a.AliasName, fmt.Sprintf("%d", a.VersionNum)})
+			}
+			ui.PrintTable([]string{"Alias", "Version"}, rows)
+		}
+
+		_, choice, err := ui.SelectPrompt("Choose Action", []string{"➕ Set Alias", "➖ Remove Alias", "⬅️  Back"})
+		if err != nil || choice == "⬅️  Back" {
+			return
+		}
+
+		switch choice {
+		case "➕ Set Alias":
+			alias, err := ui.InputPrompt("Alias (e.g. champion, challenger)", "champion")
+			if err != nil || alias == "" {
+				continue
+			}
+			versions, aliases, err := loadModelVersions(ctx, w, fullName)
+			if err != nil {
+				ui.PrintError(err.Error())
+				continue
+			}
+			version, ok := selectModelVersion(versions, aliases, fmt.Sprintf("Point %s at", alias))
+			if !ok {
+				continue
+			}
+			if err := pkgcatalog.SetModelAlias(ctx, w, fullName, alias, version); err != nil {
+				ui.PrintError(err.Error())
+				continue
+			}
+			ui.PrintSuccess(fmt.Sprintf("%s -> version %d", alias, version))
+		case "➖ Remove Alias":
+			if len(model.Aliases) == 0 {
+				continue
+			}
+			items := make([]string, len(model.Aliases))
+			for i, a := range model.Aliases {
+				items[i] = a.AliasName
+			}
+			_, alias, err := ui.SelectPrompt("Remove Alias", items)
+			if err != nil || !ui.ConfirmPrompt(fmt.Sprintf("Remove alias %s", alias)) {
+				continue
+			}
+			if err := pkgcatalog.DeleteModelAlias(ctx, w, fullName, alias); err != nil {
+				ui.PrintError(err.Error())
+				continue
+			}
+			ui.PrintSuccess(fmt.Sprintf("Removed alias %s", alias))
+		}
+	}
+}
+
+// selectModelVersion prompts for one of the given versions.
+func selectModelVersion(versions []catalog.ModelVersionInfo, aliases map[int][]string, label string) (int, bool) {
+	if len(versions) == 0 {
+		ui.PrintInfo("No versions found.")
+		return 0, false
+	}
+
+	items := make([]string, len(versions))
+	for i, v := range versions {
+		items[i] = fmt.Sprintf("Version %d (%s)", v.Version, v.Status)
+		if a := aliases[v.Version]; len(a) > 0 {
+			items[i] += " @" + strings.Join(a, ", @")
+		}
+	}
+	idx, _, err := ui.SelectPrompt(label, items)
+	if err != nil {
+		return 0, false
+	}
+	return versions[idx].Version, true
+}
+
+func compareModelVersions(ctx context.Context, w *databricks.WorkspaceClient, fullName string) {
+	versions, aliases, err := loadModelVersions(ctx, w, fullName)
+	if err != nil {
+		ui.PrintError(err.Error())
+		return
+	}
+	a, ok := selectModelVersion(versions, aliases, "First Version")
+	if !ok {
+		return
+	}
+	b, ok := selectModelVersion(versions, aliases, "Second Version")
+	if !ok {
+		return
+	}
+
+	va, err := pkgcatalog.GetModelVersion(ctx, w, fullName, a)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to get version %d: %v", a, err))
+		return
+	}
+	vb, err := pkgcatalog.GetModelVersion(ctx, w, fullName, b)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to get version %d: %v", b, err))
+		return
+	}
+
+	fa, fb := modelVersionFields(*va, aliases[a]), modelVersionFields(*vb, aliases[b])
+	var rows [][]string
+	var changed []bool
+	for i := range fa {
+		rows = append(rows, []string{fa[i][0], fa[i][1], fb[i][1]})
+		changed = append(changed, fa[i][1] != fb[i][1])
+	}
+	ui.PrintHeader(fmt.Sprintf("%s: version %d vs %d", fullName, a, b))
+	ui.PrintTableWithHighlights([]string{"Field", fmt.Sprintf("Version %d", a), fmt.Sprintf("Version %d", b)}, rows, changed)
+	ui.PrintInfo("Highlighted rows differ.")
+	fmt.Println("\nPress Enter to continue...")
+	fmt.Scanln()
+}
+
+// formatMillis renders a Unity Catalog epoch-millisecond timestamp.
+func formatMillis(ms int64) string {
+	if ms == 0 {
+		return "-"
+	}
+	return time.UnixMilli(ms).Format("2006-01-02 15:04:05")
+}
+
+// applyWizardOperation shows the request and the equivalent CLI command, asks for
+// confirmation (typing confirmName when set) and applies it.
+func applyWizardOperation(ctx context.Context, w *databricks.WorkspaceClient, op *pkgcatalog.Operation, err error, confirmName string) bool {
+	if err != nil {
+		ui.PrintError(err.Error())
+		return false
+	}
+	printOperation(op)
+	if !confirmOperation(op, confirmName) {
+		return false
+	}
+	if err := op.Apply(ctx, w); err != nil {
+		ui.PrintError(err.Error())
+		return false
+	}
+	ui.PrintSuccess(op.Summary + ": done.")
+	return true
+}
+
+func createCatalogWizard(ctx context.Context, w *databricks.WorkspaceClient) {
+	name, err := ui.InputPrompt("Catalog Name", "")
+	if err != nil || name == "" {
+		return
+	}
+	_, kind, err := ui.SelectPrompt("Catalog Type", []string{"🗄  Managed", "🌐 Foreign (Federation)", "🤝 Shared (Delta Sharing)", "⬅️  Back"})
+	if err != nil || kind == "⬅️  Back" {
+		return
+	}
+
+	req := catalog.CreateCatalog{Name: name}
+	switch kind {
+	case "🗄  Managed":
+		if req.StorageRoot, err = ui.InputPrompt("Storage Root (empty for the metastore default)", ""); err != nil {
+			return
+		}
+	case "🌐 Foreign (Federation)":
+		conns, err := pkgcatalog.ListConnections(ctx, w)
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to list connections: %v", err))
+			return
+		}
+		if len(conns) == 0 {
+			ui.PrintInfo("No connections found.")
+			return
+		}
+		items := make([]string, len(conns))
+		for i, c := range conns {
+			items[i] = fmt.Sprintf("%s (%s)", c.Name, c.ConnectionType)
+		}
+		idx, _, err := ui.SelectPrompt("Connection", items)
+		if err != nil {
+			return
+		}
+		req.ConnectionName = conns[idx].Name
+		req.Options = map[string]string{}
+		for label := "Option (key=value)"; ; label = "Option (key=value, empty to finish)" {
+			input, err := ui.InputPrompt(label, "")
+			if err != nil {
+				return
+			}
+			if input == "" {
+				break
+			}
+			k, v, ok := strings.Cut(input, "=")
+			if !ok || strings.TrimSpace(k) == "" {
+				ui.PrintError(fmt.Sprintf("Invalid option %q: expected key=value, e.g. database=shop.", input))
+				continue
+			}
+			req.Options[strings.TrimSpace(k)] = v
+		}
+	case "🤝 Shared (Delta Sharing)":
+		if req.ProviderName, err = ui.InputPrompt("Provider", ""); err != nil {
+			return
+		}
+		if req.ShareName, err = ui.InputPrompt("Share", ""); err != nil {
+			return
+		}
+	}
+	if req.Comment, err = ui.InputPrompt("Comment", ""); err != nil {
+		return
+	}
+
+	op, err := pkgcatalog.CreateCatalog(req)
+	applyWizardOperation(ctx, w, op, err, "")
+}
+
+func createSchemaWizard(ctx context.Context, w *databricks.WorkspaceClient, catalogName string) {
+	name, err := ui.InputPrompt("Schema Name", "")
+	if err != nil || name == "" {
+		return
+	}
+	req := catalog.CreateSchema{CatalogName: catalogName, Name: name}
+	if req.StorageRoot, err = ui.InputPrompt("Managed Location (empty to inherit from the catalog)", ""); err != nil {
+		return
+	}
+	if req.Comment, err = ui.InputPrompt("Comment", ""); err != nil {
+		return
+	}
+	op, err := pkgcatalog.CreateSchema(req)
+	applyWizardOperation(ctx, w, op, err, "")
+}
+
+func createVolumeWizard(ctx context.Context, w *databricks.WorkspaceClient, catalogName, schemaName string) {
+	name, err := ui.InputPrompt("Volume Name", "")
+	if err != nil || name == "" {
+		return
+	}
+	_, kind, err := ui.SelectPrompt("Volume Type", []string{"Managed", "External"})
+	if err != nil {
+		return
+	}
+	req := catalog.CreateVolumeRequestContent{CatalogName: catalogName, SchemaName: schemaName, Name: name, VolumeType: catalog.VolumeTypeManaged}
+	if kind == "External" {
+		req.VolumeType = catalog.VolumeTypeExternal
+		if req.StorageLocation, err = ui.InputPrompt("Storage Location", ""); err != nil {
+			return
+		}
+	}
+	if req.Comment, err = ui.InputPrompt("Comment", ""); err != nil {
+		return
+	}
+	op, err := pkgcatalog.CreateVolume(req)
+	applyWizardOperation(ctx, w, op, err, "")
+}
+
+// renameWizard asks for the new name and reports whether the object was
+// renamed.
+func renameWizard(ctx context.Context, w *databricks.WorkspaceClient, kind pkgcatalog.ObjectKind, fullName string) bool {
+	current := fullName[strings.LastIndex(fullName, ".")+1:]
+	newName, err := ui.InputPrompt("New Name", current)
+	if err != nil || newName == "" || newName == current {
+		return false
+	}
+	op, err := pkgcatalog.Rename(kind, fullName, newName)
+	return applyWizardOperation(ctx, w, op, err, "")
+}
+
+// dropWizard asks for the full name to be typed and reports whether the
+// object was dropped.
+func dropWizard(ctx context.Context, w *databricks.WorkspaceClient, kind pkgcatalog.ObjectKind, fullName string) bool {
+	force := false
+	if kind == pkgcatalog.KindCatalog || kind == pkgcatalog.KindSchema {
+		force = ui.ConfirmPrompt(fmt.Sprintf("Also drop everything in the %s (force)", kind))
+	}
+	op, err := pkgcatalog.Drop(kind, fullName, force)
+	return applyWizardOperation(ctx, w, op, err, fullName)
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	pkgcatalog "dbx-explore/pkg/catalog"
+	"dbx-explore/pkg/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	ownerFrom    string
+	ownerTo      string
+	ownerScopes  []string
+	ownerDryRun  bool
+	ownerYes     bool
+	ownerJournal string
+)
+
+var ownerCmd = &cobra.Command{
+	Use:   "owner",
+	Short: "Manage ownership of Unity Catalog objects",
+}
+
+var ownerTransferCmd = &cobra.Command{
+	Use:   "transfer",
+	Short: "Reassign every object owned by one principal to another",
+	Long: `Find all catalogs, schemas, tables, views, volumes, functions and registered
+models owned by --from and make --to their owner.
+
+Progress is recorded in a journal file. If a run fails or is interrupted,
+re-running the same command resumes from the journal; objects already
+transferred or skipped are not touched again.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+
+		journal, err := pkgcatalog.LoadOwnerJournal(ownerJournal)
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		scopes := ownerScopes
+		if len(scopes) == 0 && journal != nil {
+			// Resume over the catalogs of the first run, even if some were
+			// created or dropped since.
+			scopes = journal.Scopes
+		}
+		if len(scopes) == 0 {
+			catalogs, err := pkgcatalog.ListCatalogs(ctx, w)
+			if err != nil {
+				ui.PrintError(fmt.Sprintf("Failed to list catalogs: %v", err))
+				os.Exit(1)
+			}
+			for _, c := range catalogs {
+				scopes = append(scopes, c.Name)
+			}
+		}
+		if journal != nil && !journal.Matches(ownerFrom, ownerTo, scopes) {
+			ui.PrintError(fmt.Sprintf("Journal %s belongs to a different transfer (%s -> %s). Remove it or pass --journal.", ownerJournal, journal.From, journal.To))
+			os.Exit(1)
+		}
+
+		if journal != nil {
+			ui.PrintInfo(fmt.Sprintf("Resuming from %s: %d done, %d skipped, %d remaining.",
+				ownerJournal, journal.Count(pkgcatalog.JournalDone), journal.Count(pkgcatalog.JournalSkipped),
+				journal.Count(pkgcatalog.JournalPending)+journal.Count(pkgcatalog.JournalFailed)))
+		} else {
+			ui.PrintInfo(fmt.Sprintf("Searching for objects owned by %s...", ownerFrom))
+			owned, err := pkgcatalog.FindOwnedSecurables(ctx, w, scopes, ownerFrom)
+			if err != nil {
+				ui.PrintError(err.Error())
+				os.Exit(1)
+			}
+			journal = pkgcatalog.NewOwnerJournal(ownerJournal, ownerFrom, ownerTo, scopes, owned)
+		}
+
+		var rows [][]string
+		for _, e := range journal.Entries {
+			rows = append(rows, []string{string(e.Securable.Kind), e.Securable.FullName, e.Status})
+		}
+		ui.PrintTable([]string{"Kind", "Object", "Status"}, rows)
+
+		if len(journal.Entries) == 0 {
+			ui.PrintSuccess(fmt.Sprintf("Nothing owned by %s.", ownerFrom))
+			return
+		}
+		if ownerDryRun {
+			ui.PrintInfo(fmt.Sprintf("Dry run: %d objects would be transferred to %s.", len(journal.Entries), ownerTo))
+			return
+		}
+		if err := journal.Save(); err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		applyAll := ownerYes
+		for i, e := range journal.Entries {
+			if e.Status == pkgcatalog.JournalDone || e.Status == pkgcatalog.JournalSkipped {
+				continue
+			}
+
+			if !applyAll {
+				_, choice, err := ui.SelectPrompt(fmt.Sprintf("Transfer %s %s to %s?", e.Securable.Kind, e.Securable.FullName, ownerTo),
+					[]string{"✅ Transfer", "⏭️  Skip", "⏩ Transfer All Remaining", "❌ Abort"})
+				if err != nil || choice == "❌ Abort" {
+					ui.PrintInfo(fmt.Sprintf("Aborted. Re-run the command to resume from %s.", ownerJournal))
+					return
+				}
+				if choice == "⏭️  Skip" {
+					if err := journal.Set(i, pkgcatalog.JournalSkipped, nil); err != nil {
+						ui.PrintError(err.Error())
+						os.Exit(1)
+					}
+					continue
+				}
+				applyAll = choice == "⏩ Transfer All Remaining"
+			}
+
+			transferErr := pkgcatalog.SetOwner(ctx, w, e.Securable, ownerTo)
+			status := pkgcatalog.JournalDone
+			if transferErr != nil {
+				status = pkgcatalog.JournalFailed
+				ui.PrintError(transferErr.Error())
+			} else {
+				ui.PrintSuccess(fmt.Sprintf("%s -> %s", e.Securable.FullName, ownerTo))
+			}
+			if err := journal.Set(i, status, transferErr); err != nil {
+				ui.PrintError(err.Error())
+				os.Exit(1)
+			}
+		}
+
+		failed := journal.Count(pkgcatalog.JournalFailed)
+		ui.PrintInfo(fmt.Sprintf("%d transferred, %d skipped, %d failed.",
+			journal.Count(pkgcatalog.JournalDone), journal.Count(pkgcatalog.JournalSkipped), failed))
+		if failed > 0 {
+			ui.PrintError(fmt.Sprintf("Fix the errors above and re-run to retry; progress is kept in %s.", ownerJournal))
+			os.Exit(1)
+		}
+		if err := journal.Remove(); err != nil {
+			ui.PrintError(err.Error())
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(ownerCmd)
+	ownerCmd.AddCommand(ownerTransferCmd)
+	ownerTransferCmd.Flags().StringVar(&ownerFrom, "from", "", "Current owner (user, group or service principal)")
+	ownerTransferCmd.Flags().StringVar(&ownerTo, "to", "", "New owner")
+	ownerTransferCmd.Flags().StringSliceVar(&ownerScopes, "scope", nil, "Limit to <catalog> or <catalog>.<schema>; repeatable (default: all catalogs)")
+	ownerTransferCmd.Flags().BoolVar(&ownerDryRun, "dry-run", false, "List the objects that would be transferred without changing them")
+	ownerTransferCmd.Flags().BoolVarP(&ownerYes, "yes", "y", false, "Transfer every object without asking")
+	ownerTransferCmd.Flags().StringVar(&ownerJournal, "journal", ".owner-transfer.json", "Journal file used to resume an interrupted transfer")
+	_ = ownerTransferCmd.MarkFlagRequired("from")
+	_ = ownerTransferCmd.MarkFlagRequired("to")
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	pkgcatalog "dbx-explore/pkg/catalog"
+	"dbx-explore/pkg/query"
+	"dbx-explore/pkg/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileSample float64
+	profileOutput string
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile <catalog.schema.table>",
+	Short: "Profile the columns of a table",
+	Long: `Compute column statistics for a table in a single aggregate query on the
+configured SQL warehouse (DATABRICKS_WAREHOUSE_ID): null percentage and
+approximate distinct count for every column, min/max/avg for numbers,
+min/max for dates and timestamps, and length statistics and the most frequent
+values for strings.
+
+Use --sample to profile a TABLESAMPLE percentage of a large table.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+		warehouseID := configuredWarehouseID(true)
+
+		tableInfo, err := pkgcatalog.GetTable(ctx, w, args[0])
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to get table details: %v", err))
+			os.Exit(1)
+		}
+
+		if profileOutput != "json" {
+			ui.PrintInfo(fmt.Sprintf("Profiling %d columns of %s...", len(tableInfo.Columns), tableInfo.FullName))
+		}
+		p, err := query.ProfileTable(ctx, w, warehouseID, tableInfo.FullName, tableInfo.Columns, profileSample)
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		if profileOutput == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(p); err != nil {
+				ui.PrintError(fmt.Sprintf("Failed to encode profile: %v", err))
+				os.Exit(1)
+			}
+			return
+		}
+		printTableProfile(p)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.Flags().Float64Var(&profileSample, "sample", 0, "Profile a TABLESAMPLE percentage of the table (0 = full table)")
+	profileCmd.Flags().StringVarP(&profileOutput, "output", "o", "table", "Output format: table or json")
+}
+
+// printTableProfile prints one compact row per column.
+func printTableProfile(p *query.TableProfile) {
+	title := fmt.Sprintf("Profile: %s (%d rows)", p.Table, p.Rows)
+	if p.SamplePercent > 0 {
+		title = fmt.Sprintf("Profile: %s (%d rows in a %g%% sample)", p.Table, p.Rows, p.SamplePercent)
+	}
+	ui.PrintHeader(title)
+
+	rows := make([][]string, len(p.Columns))
+	for i, c := range p.Columns {
+		distinct := "-"
+		if c.Class != query.ClassOther {
+			distinct = fmt.Sprintf("%d", c.Distinct)
+		}
+		length := "-"
+		if c.MaxLength != "" {
+			length = fmt.Sprintf("%s / %s / %s", c.MinLength, c.AvgLength, c.MaxLength)
+		}
+		top := make([]string, len(c.TopValues))
+		for j, v := range c.TopValues {
+			top[j] = fmt.Sprintf("%s (%d)", v.Value, v.Count)
+		}
+		rows[i] = []string{
+			c.Name,
+			c.Type,
+			fmt.Sprintf("%.1f%%", c.NullPct),
+			distinct,
+			valueOrDash(c.Min),
+			valueOrDash(c.Max),
+			valueOrDash(c.Avg),
+			length,
+			valueOrDash(ui.Truncate(strings.Join(top, ", "), ui.MaxCellWidth)),
+		}
+	}
+	ui.PrintTable([]string{"Column", "Type", "Null %", "~Distinct", "Min", "Max", "Avg", "Length (min/avg/max)", "Top Values"}, rows)
+}
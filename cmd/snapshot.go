@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	pkgcatalog "dbx-explore/pkg/catalog"
+	"dbx-explore/pkg/diff"
+	"dbx-explore/pkg/ui"
+
+	"github.com/spf13/cobra"
+)
+
+// Exit codes of drift check.
+const (
+	exitDriftNone     = 0
+	exitDriftError    = 1
+	exitDriftBreaking = 2
+)
+
+var (
+	snapshotCatalogs []string
+	snapshotOutput   string
+	snapshotNoGrants bool
+	snapshotFormat   string
+	driftBaseline    string
+	driftCurrent     string
+	driftAllow       []string
+	driftFormat      string
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Record and compare metastore structure over time",
+}
+
+var snapshotSaveCmd = &cobra.Command{
+	Use:   "save",
+	Short: "Save catalogs, schemas, tables, columns, owners and grants to a snapshot file",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+
+		snap, err := pkgcatalog.TakeSnapshot(ctx, w, snapshotCatalogs, !snapshotNoGrants, func(name string) {
+			ui.PrintInfo(fmt.Sprintf("Reading catalog %s...", name))
+		})
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Snapshot failed: %v", err))
+			os.Exit(1)
+		}
+
+		path := snapshotOutput
+		if path == "" {
+			path = fmt.Sprintf("snapshot-%s.json", snap.CreatedAt.Format("20060102T150405Z"))
+		}
+		if err := pkgcatalog.SaveSnapshot(path, snap); err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+		tables := 0
+		for _, c := range snap.Catalogs {
+			for _, s := range c.Schemas {
+				tables += len(s.Tables)
+			}
+		}
+		ui.PrintSuccess(fmt.Sprintf("Saved %d catalogs and %d tables to %s", len(snap.Catalogs), tables, path))
+	},
+}
+
+var snapshotDiffCmd = &cobra.Command{
+	Use:   "diff <old-snapshot> <new-snapshot>",
+	Short: "Show what changed between two snapshots",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		a, err := pkgcatalog.LoadSnapshot(args[0])
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+		b, err := pkgcatalog.LoadSnapshot(args[1])
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		changes := reportChanges(pkgcatalog.DiffSnapshots(a, b), nil)
+		if snapshotFormat == "json" {
+			printChangesJSON(changes)
+			return
+		}
+		lines := diff.Unified(pkgcatalog.RenderSnapshot(a), pkgcatalog.RenderSnapshot(b),
+			fmt.Sprintf("%s (%s)", args[0], a.CreatedAt.Format(time.RFC3339)),
+			fmt.Sprintf("%s (%s)", args[1], b.CreatedAt.Format(time.RFC3339)), 3)
+		if len(lines) == 0 {
+			ui.PrintSuccess("No changes.")
+			return
+		}
+		ui.PrintUnifiedDiff(lines)
+		printChangeSummary(changes)
+	},
+}
+
+var driftCmd = &cobra.Command{
+	Use:   "drift",
+	Short: "Detect schema drift against a baseline snapshot",
+}
+
+var driftCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Compare the live metastore with a baseline snapshot",
+	Long: `Compare the live metastore (or a --current snapshot) with a baseline snapshot
+and report what changed. Only the catalogs in the baseline are read.
+
+Breaking changes are removed catalogs, schemas, tables or columns, changed
+table or column types, columns that became NOT NULL and revoked privileges.
+Use --allow with a JSON Pointer pattern (path.Match syntax, e.g.
+/catalogs/dev or /catalogs/*/schemas/tmp_*) to accept expected changes at or
+below a path.
+
+Exit codes: 0 = no breaking changes, 1 = error, 2 = breaking changes.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		baseline, err := pkgcatalog.LoadSnapshot(driftBaseline)
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(exitDriftError)
+		}
+
+		var current *pkgcatalog.Snapshot
+		if driftCurrent != "" {
+			current, err = pkgcatalog.LoadSnapshot(driftCurrent)
+		} else {
+			current, err = pkgcatalog.TakeSnapshot(context.Background(), getWorkspaceClient(), baseline.CatalogNames(), baseline.WithGrants, func(name string) {
+				if driftFormat == "text" {
+					ui.PrintInfo(fmt.Sprintf("Reading catalog %s...", name))
+				}
+			})
+		}
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(exitDriftError)
+		}
+
+		changes := reportChanges(pkgcatalog.DiffSnapshots(baseline, current), driftAllow)
+		if driftFormat == "json" {
+			printChangesJSON(changes)
+		} else if len(changes) == 0 {
+			ui.PrintSuccess(fmt.Sprintf("No drift since %s.", baseline.CreatedAt.Format(time.RFC3339)))
+		} else {
+			printChangeTable(changes)
+			printChangeSummary(changes)
+		}
+
+		for _, c := range changes {
+			if c.Breaking && !c.Allowed {
+				os.Exit(exitDriftBreaking)
+			}
+		}
+		os.Exit(exitDriftNone)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.AddCommand(snapshotSaveCmd)
+	snapshotCmd.AddCommand(snapshotDiffCmd)
+	rootCmd.AddCommand(driftCmd)
+	driftCmd.AddCommand(driftCheckCmd)
+
+	snapshotSaveCmd.Flags().StringSliceVar(&snapshotCatalogs, "catalog", nil, "Catalog to include; repeatable (default: all but system catalogs)")
+	snapshotSaveCmd.Flags().StringVarP(&snapshotOutput, "output", "o", "", "Snapshot file (default: snapshot-<UTC time>.json)")
+	snapshotSaveCmd.Flags().BoolVar(&snapshotNoGrants, "no-grants", false, "Skip direct grants")
+
+	snapshotDiffCmd.Flags().StringVarP(&snapshotFormat, "output", "o", "diff", "Output format: diff or json")
+
+	driftCheckCmd.Flags().StringVar(&driftBaseline, "baseline", "", "Baseline snapshot file")
+	driftCheckCmd.Flags().StringVar(&driftCurrent, "current", "", "Compare with this snapshot instead of the live metastore")
+	driftCheckCmd.Flags().StringSliceVar(&driftAllow, "allow", nil, "Accept changes at or below a path pattern; repeatable")
+	driftCheckCmd.Flags().StringVarP(&driftFormat, "output", "o", "text", "Output format: text or json")
+	_ = driftCheckCmd.MarkFlagRequired("baseline")
+}
+
+// reportedChange is a change with its classification.
+type reportedChange struct {
+	pkgcatalog.Change
+	Breaking bool `json:"breaking"`
+	Allowed  bool `json:"allowed,omitempty"`
+}
+
+func reportChanges(changes []pkgcatalog.Change, allow []string) []reportedChange {
+	reported := make([]reportedChange, len(changes))
+	for i, c := range changes {
+		reported[i] = reportedChange{Change: c, Breaking: pkgcatalog.IsBreaking(c), Allowed: pkgcatalog.MatchesAny(c.Path, allow)}
+	}
+	return reported
+}
+
+func printChangesJSON(changes []reportedChange) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(changes); err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to encode changes: %v", err))
+		os.Exit(1)
+	}
+}
+
+func printChangeTable(changes []reportedChange) {
+	rows := make([][]string, len(changes))
+	highlighted := make([]bool, len(changes))
+	for i, c := range changes {
+		status := ""
+		switch {
+		case c.Breaking && c.Allowed:
+			status = "allowed"
+		case c.Breaking:
+			status = "⚠️  breaking"
+		}
+		rows[i] = []string{c.Op, c.Path, status}
+		highlighted[i] = c.Breaking && !c.Allowed
+	}
+	ui.PrintTableWithHighlights([]string{"Op", "Path", "Status"}, rows, highlighted)
+}
+
+func printChangeSummary(changes []reportedChange) {
+	breaking, allowed := 0, 0
+	for _, c := range changes {
+		if c.Breaking {
+			breaking++
+			if c.Allowed {
+				allowed++
+			}
+		}
+	}
+	msg := fmt.Sprintf("%d changes, %d breaking", len(changes), breaking)
+	if allowed > 0 {
+		msg += fmt.Sprintf(" (%d allowed)", allowed)
+	}
+	ui.PrintInfo(msg + ".")
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	pkgcatalog "dbx-explore/pkg/catalog"
+	"dbx-explore/pkg/query"
+	"dbx-explore/pkg/ui"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// statsCacheFile keeps table stats between runs.
+	statsCacheFile = ".table-stats.json"
+	// statsMaxAge is how long cached stats are reused.
+	statsMaxAge = time.Hour
+
+	defaultStatsConcurrency = 8
+)
+
+var (
+	statsSort        string
+	statsRefresh     bool
+	statsConcurrency int
+	statsOutput      string
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats <catalog.schema | catalog.schema.table>",
+	Short: "Show row counts and sizes of a table or every table in a schema",
+	Long: `Collect row count, size in bytes, number of files and last modification time
+from DESCRIBE DETAIL on the configured SQL warehouse (DATABRICKS_WAREHOUSE_ID).
+
+For a schema, all tables are collected concurrently. Views are skipped. Results
+are cached in .table-stats.json for an hour; pass --refresh to collect again.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		if err := query.SortStats(nil, statsSort); err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+		if statsOutput != "table" && statsOutput != "json" && statsOutput != "csv" {
+			ui.PrintError(fmt.Sprintf("Unknown output format %q (use table, json or csv).", statsOutput))
+			os.Exit(1)
+		}
+		w := getWorkspaceClient()
+		warehouseID := configuredWarehouseID(true)
+
+		var tables []string
+		switch parts := strings.Split(args[0], "."); len(parts) {
+		case 3:
+			tables = []string{args[0]}
+		case 2:
+			infos, err := pkgcatalog.ListTables(ctx, w, parts[0], parts[1])
+			if err != nil {
+				ui.PrintError(fmt.Sprintf("Failed to list tables: %v", err))
+				os.Exit(1)
+			}
+			tables = statsTables(infos)
+		default:
+			ui.PrintError(fmt.Sprintf("Expected <catalog.schema> or <catalog.schema.table>, got %q.", args[0]))
+			os.Exit(1)
+		}
+
+		stats, err := collectStats(ctx, w, warehouseID, tables, statsRefresh, statsConcurrency, statsOutput == "table")
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+		_ = query.SortStats(stats, statsSort)
+
+		if err := writeStats(os.Stdout, stats, statsOutput); err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().StringVar(&statsSort, "sort", "size", "Sort by "+strings.Join(query.StatsSortKeys, ", "))
+	statsCmd.Flags().BoolVar(&statsRefresh, "refresh", false, "Ignore cached stats")
+	statsCmd.Flags().IntVar(&statsConcurrency, "concurrency", defaultStatsConcurrency, "Tables collected in parallel")
+	statsCmd.Flags().StringVarP(&statsOutput, "output", "o", "table", "Output format: table, json or csv")
+}
+
+// statsTables returns the full names of the tables that DESCRIBE DETAIL
+// supports, skipping views.
+func statsTables(infos []catalog.TableInfo) []string {
+	var tables []string
+	for _, t := range infos {
+		if t.TableType == catalog.TableTypeView {
+			continue
+		}
+		tables = append(tables, t.FullName)
+	}
+	return tables
+}
+
+// collectStats returns stats for tables in order, reusing cached entries
+// unless refresh is set and saving new ones to the cache.
+func collectStats(ctx context.Context, w *databricks.WorkspaceClient, warehouseID string, tables []string, refresh bool, concurrency int, progress bool) ([]query.TableStats, error) {
+	cache, err := query.LoadStatsCache(statsCacheFile)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]query.TableStats, len(tables))
+	var missing []string
+	var missingIdx []int
+	for i, t := range tables {
+		if s, ok := cache.Get(t, statsMaxAge); ok && !refresh {
+			stats[i] = s
+			continue
+		}
+		missing = append(missing, t)
+		missingIdx = append(missingIdx, i)
+	}
+	if len(missing) == 0 {
+		return stats, nil
+	}
+
+	if progress {
+		ui.PrintInfo(fmt.Sprintf("Collecting stats for %d tables (%d cached)...", len(missing), len(tables)-len(missing)))
+	}
+	var mu sync.Mutex
+	done := 0
+	collected := query.CollectSchemaStats(ctx, w, warehouseID, missing, concurrency, func(s query.TableStats) {
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		if progress {
+			fmt.Fprintf(os.Stderr, "\r⏳ %d/%d tables   ", done, len(missing))
+		}
+	})
+	if progress {
+		fmt.Fprintln(os.Stderr)
+	}
+
+	for j, s := range collected {
+		stats[missingIdx[j]] = s
+		cache.Put(s)
+	}
+	if err := cache.Save(); err != nil {
+		ui.PrintError(err.Error())
+	}
+	return stats, nil
+}
+
+// writeStats renders stats as a table, JSON or CSV.
+func writeStats(out io.Writer, stats []query.TableStats, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	case "csv":
+		return query.WriteStatsCSV(out, stats)
+	case "table":
+		printStats(stats)
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q (use table, json or csv)", format)
+	}
+}
+
+// printStats prints one row per table followed by the totals.
+func printStats(stats []query.TableStats) {
+	var rows [][]string
+	var totalRows, totalBytes, totalFiles int64
+	failed := 0
+	for _, s := range stats {
+		if s.Error != "" {
+			failed++
+			rows = append(rows, []string{s.Table, "-", "-", "-", "-", "❌ " + ui.Truncate(s.Error, ui.MaxCellWidth)})
+			continue
+		}
+		totalRows += s.Rows
+		totalBytes += s.SizeInBytes
+		totalFiles += s.NumFiles
+		rows = append(rows, []string{
+			s.Table,
+			fmt.Sprintf("%d", s.Rows),
+			ui.FormatBytes(s.SizeInBytes),
+			fmt.Sprintf("%d", s.NumFiles),
+			valueOrDash(s.LastModified),
+			s.CollectedAt.Local().Format("2006-01-02 15:04"),
+		})
+	}
+	ui.PrintTable([]string{"Table", "Rows", "Size", "Files", "Last Modified", "Collected"}, rows)
+	summary := fmt.Sprintf("%d tables, %d rows, %s in %d files.", len(stats)-failed, totalRows, ui.FormatBytes(totalBytes), totalFiles)
+	if failed > 0 {
+		summary += fmt.Sprintf(" %d failed.", failed)
+	}
+	ui.PrintInfo(summary)
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	pkgcatalog "dbx-explore/pkg/catalog"
+	"dbx-explore/pkg/query"
+	"dbx-explore/pkg/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	tagsFindCatalog string
+	tagsFindTypes   []string
+	tagsOutput      string
+)
+
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "Show, set and find governance tags",
+	Long: `Show, set and remove governance tags on catalogs, schemas, tables, columns
+and volumes, and find every object carrying a tag.
+
+Entity types are catalog, schema, table (or view), column and volume. Columns
+are named catalog.schema.table.column.`,
+}
+
+var tagsShowCmd = &cobra.Command{
+	Use:   "show <entity-type> <full-name>",
+	Short: "Show the tags on an object",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		entityType := mustParseTagEntityType(args[0])
+		tags, err := pkgcatalog.ListTags(context.Background(), getWorkspaceClient(), entityType, args[1])
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+		if tagsOutput == "json" {
+			printTagsJSON(tags)
+			return
+		}
+		printTags(tags)
+	},
+}
+
+var tagsSetCmd = &cobra.Command{
+	Use:   "set <entity-type> <full-name> <key[=value]>...",
+	Short: "Set one or more tags on an object",
+	Args:  cobra.MinimumNArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		entityType := mustParseTagEntityType(args[0])
+		var tags []pkgcatalog.Tag
+		for _, a := range args[2:] {
+			tag, err := pkgcatalog.ParseTag(a)
+			if err != nil {
+				ui.PrintError(err.Error())
+				os.Exit(1)
+			}
+			tags = append(tags, tag)
+		}
+
+		w := getWorkspaceClient()
+		for _, tag := range tags {
+			if err := pkgcatalog.SetTag(ctx, w, entityType, args[1], tag); err != nil {
+				ui.PrintError(err.Error())
+				os.Exit(1)
+			}
+			ui.PrintSuccess(fmt.Sprintf("Set %s on %s", tag, args[1]))
+		}
+	},
+}
+
+var tagsUnsetCmd = &cobra.Command{
+	Use:   "unset <entity-type> <full-name> <key>...",
+	Short: "Remove one or more tags from an object",
+	Args:  cobra.MinimumNArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		entityType := mustParseTagEntityType(args[0])
+		w := getWorkspaceClient()
+		for _, key := range args[2:] {
+			if err := pkgcatalog.UnsetTag(ctx, w, entityType, args[1], key); err != nil {
+				ui.PrintError(err.Error())
+				os.Exit(1)
+			}
+			ui.PrintSuccess(fmt.Sprintf("Removed %s from %s", key, args[1]))
+		}
+	},
+}
+
+var tagsFindCmd = &cobra.Command{
+	Use:   "find <key[=value]>",
+	Short: "List every object carrying a tag",
+	Long: `List every catalog, schema, table, column and volume carrying a tag, or a
+tag with a specific value. The search reads system.information_schema on the
+configured SQL warehouse (DATABRICKS_WAREHOUSE_ID), or the information_schema
+of one catalog with --catalog.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tag, err := pkgcatalog.ParseTag(args[0])
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+		var types []string
+		for _, t := range tagsFindTypes {
+			types = append(types, mustParseTagEntityType(t))
+		}
+		w := getWorkspaceClient()
+		warehouseID := configuredWarehouseID(true)
+
+		found, err := query.FindTags(context.Background(), w, warehouseID, tag.Key, tag.Value, tagsFindCatalog, types)
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Tag search failed: %v", err))
+			os.Exit(1)
+		}
+		if tagsOutput == "json" {
+			printTagsJSON(found)
+			return
+		}
+		if len(found) == 0 {
+			ui.PrintInfo(fmt.Sprintf("No objects tagged %s.", tag))
+			return
+		}
+		rows := make([][]string, len(found))
+		for i, f := range found {
+			rows[i] = []string{strings.TrimSuffix(f.Type, "s"), f.Name, f.TagKey, valueOrDash(f.TagValue)}
+		}
+		ui.PrintTable([]string{"Type", "Name", "Tag", "Value"}, rows)
+		ui.PrintInfo(fmt.Sprintf("%d objects tagged %s.", len(found), tag))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tagsCmd)
+	tagsCmd.AddCommand(tagsShowCmd, tagsSetCmd, tagsUnsetCmd, tagsFindCmd)
+
+	for _, c := range []*cobra.Command{tagsShowCmd, tagsFindCmd} {
+		c.Flags().StringVarP(&tagsOutput, "output", "o", "table", "Output format: table or json")
+	}
+	tagsFindCmd.Flags().StringVar(&tagsFindCatalog, "catalog", "", "Only search this catalog")
+	tagsFindCmd.Flags().StringSliceVar(&tagsFindTypes, "type", nil, "Only return these entity types; repeatable")
+}
+
+func mustParseTagEntityType(s string) string {
+	entityType, err := pkgcatalog.ParseTagEntityType(s)
+	if err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+	return entityType
+}
+
+func printTags(tags []pkgcatalog.Tag) {
+	if len(tags) == 0 {
+		ui.PrintInfo("No tags.")
+		return
+	}
+	rows := make([][]string, len(tags))
+	for i, t := range tags {
+		rows[i] = []string{t.Key, valueOrDash(t.Value)}
+	}
+	ui.PrintTable([]string{"Tag", "Value"}, rows)
+}
+
+func printTagsJSON(v interface{}) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to encode tags: %v", err))
+		os.Exit(1)
+	}
+}
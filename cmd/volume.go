@@ -0,0 +1,507 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"dbx-explore/pkg/files"
+	"dbx-explore/pkg/ui"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultPreviewBytes = 64 * 1024
+	defaultPreviewRows  = 20
+)
+
+var (
+	volumeRecursive    bool
+	volumeOverwrite    bool
+	volumePreviewBytes int64
+	volumePreviewRows  int
+	syncDelete         bool
+	syncDryRun         bool
+	syncChecksum       bool
+	syncConcurrency    int
+	syncSummaryFile    string
+)
+
+var volumeCmd = &cobra.Command{
+	Use:   "volume",
+	Short: "Browse and transfer files in Unity Catalog volumes",
+	Long: `Work with files in Unity Catalog volumes through the Files API.
+
+Volume paths look like /Volumes/<catalog>/<schema>/<volume>/path (a dbfs: prefix
+is accepted). Everything else is treated as a local path.`,
+}
+
+var volumeLsCmd = &cobra.Command{
+	Use:   "ls <volume-path>",
+	Short: "List a volume directory",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+		dir := mustVolumePath(args[0])
+
+		entries, err := files.List(ctx, w, dir)
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+		printVolumeEntries(entries)
+	},
+}
+
+var volumeGetCmd = &cobra.Command{
+	Use:   "get <volume-path> [local-path]",
+	Short: "Download a file (or directory with -r) from a volume",
+	Long: `Download a file or, with -r, a directory from a volume.
+
+Data is written to "<local-path>.part" and moved into place when complete, so
+an interrupted download resumes from there. Existing local files are skipped in
+a recursive download and refused otherwise; pass --overwrite to replace them
+and download from scratch.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		remote := mustVolumePath(args[0])
+		local := path.Base(remote)
+		if len(args) == 2 {
+			local = args[1]
+		}
+		runVolumeCopy(remote, local)
+	},
+}
+
+var volumePutCmd = &cobra.Command{
+	Use:   "put <local-path> <volume-path>",
+	Short: "Upload a file (or directory with -r) to a volume",
+	Long: `Upload a file or, with -r, a directory to a volume.
+
+Files that already exist remotely with the same size are skipped, so a failed
+recursive upload can be resumed by running the command again.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		runVolumeCopy(args[0], mustVolumePath(args[1]))
+	},
+}
+
+var volumeCpCmd = &cobra.Command{
+	Use:   "cp <src> <dst>",
+	Short: "Copy between local paths and volumes, or between two volume paths",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		runVolumeCopy(args[0], args[1])
+	},
+}
+
+var volumeRmCmd = &cobra.Command{
+	Use:   "rm <volume-path>",
+	Short: "Delete a file, or a directory with -r",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+		p := mustVolumePath(args[0])
+
+		info, err := files.Stat(ctx, w, p)
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+		if info == nil {
+			ui.PrintError(fmt.Sprintf("%s does not exist.", p))
+			os.Exit(1)
+		}
+
+		switch {
+		case info.IsDirectory && volumeRecursive:
+			err = files.DeleteRecursive(ctx, w, p)
+		case info.IsDirectory:
+			err = files.Delete(ctx, w, p, true)
+		default:
+			err = files.Delete(ctx, w, p, false)
+		}
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+		ui.PrintSuccess(fmt.Sprintf("Deleted %s", p))
+	},
+}
+
+var volumePreviewCmd = &cobra.Command{
+	Use:   "preview <volume-path>",
+	Short: "Show the first rows of a CSV, TSV, JSON, Parquet or text file",
+	Long: `Preview a volume file without downloading all of it.
+
+Only the first --bytes of the file are fetched. CSV/TSV and JSON lines are shown
+as tables, text files with line numbers. For Parquet files the footer is read
+instead and the schema and first row group statistics are shown.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+		p := mustVolumePath(args[0])
+
+		info, err := files.Stat(ctx, w, p)
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+		if info == nil || info.IsDirectory {
+			ui.PrintError(fmt.Sprintf("%s is not a file.", p))
+			os.Exit(1)
+		}
+
+		if err := previewFile(ctx, w, *info, volumePreviewBytes, volumePreviewRows); err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+var volumeSyncCmd = &cobra.Command{
+	Use:   "sync <src> <dst>",
+	Short: "Mirror a local directory to a volume directory, or the other way round",
+	Long: `Mirror a directory between the local disk and a volume. Whichever argument is
+a /Volumes path decides the direction.
+
+Only files that are new, have a different size, or were modified more recently
+at the source are transferred (--checksum compares SHA-256 hashes of same-sized
+files instead of timestamps). --delete removes destination files that no longer
+exist at the source.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		w := getWorkspaceClient()
+
+		var local, remote string
+		var dir files.SyncDirection
+		switch {
+		case files.IsVolumePath(args[0]) && !files.IsVolumePath(args[1]):
+			remote, local, dir = mustVolumePath(args[0]), args[1], files.SyncDown
+		case files.IsVolumePath(args[1]) && !files.IsVolumePath(args[0]):
+			local, remote, dir = args[0], mustVolumePath(args[1]), files.SyncUp
+		default:
+			ui.PrintError("Exactly one of <src> and <dst> must be a /Volumes path.")
+			os.Exit(1)
+		}
+
+		var mu sync.Mutex
+		opts := files.SyncOptions{
+			Delete:      syncDelete,
+			DryRun:      syncDryRun,
+			Checksum:    syncChecksum,
+			Concurrency: syncConcurrency,
+			OnComplete: func(op files.SyncOp, err error) {
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					ui.PrintError(fmt.Sprintf("%s %s: %v", op.Action, op.Path, err))
+					return
+				}
+				ui.PrintSuccess(fmt.Sprintf("%s %s", op.Action, op.Path))
+			},
+		}
+
+		summary, err := files.Sync(ctx, w, local, remote, dir, opts)
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		if syncDryRun {
+			var rows [][]string
+			for _, op := range summary.Operations {
+				rows = append(rows, []string{string(op.Action), op.Path, ui.FormatBytes(op.Size), op.Reason})
+			}
+			if len(rows) > 0 {
+				ui.PrintTable([]string{"Action", "Path", "Size", "Reason"}, rows)
+			}
+		}
+		printSyncSummary(summary)
+
+		if syncSummaryFile != "" {
+			data, _ := json.MarshalIndent(summary, "", "  ")
+			if err := os.WriteFile(syncSummaryFile, data, 0644); err != nil {
+				ui.PrintError(fmt.Sprintf("Failed to write summary: %v", err))
+				os.Exit(1)
+			}
+			ui.PrintInfo(fmt.Sprintf("Summary written to %s", syncSummaryFile))
+		}
+		if summary.Failed() > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(volumeCmd)
+	volumeCmd.AddCommand(volumeLsCmd, volumeGetCmd, volumePutCmd, volumeCpCmd, volumeRmCmd, volumePreviewCmd, volumeSyncCmd)
+
+	for _, c := range []*cobra.Command{volumeGetCmd, volumePutCmd, volumeCpCmd, volumeRmCmd} {
+		c.Flags().BoolVarP(&volumeRecursive, "recursive", "r", false, "Operate on directories recursively")
+	}
+	for _, c := range []*cobra.Command{volumeGetCmd, volumePutCmd, volumeCpCmd} {
+		c.Flags().BoolVar(&volumeOverwrite, "overwrite", false, "Replace existing files instead of resuming or skipping them")
+	}
+	volumePreviewCmd.Flags().Int64Var(&volumePreviewBytes, "bytes", defaultPreviewBytes, "Number of bytes to fetch from the start of the file")
+	volumePreviewCmd.Flags().IntVar(&volumePreviewRows, "rows", defaultPreviewRows, "Maximum number of rows or lines to show")
+
+	volumeSyncCmd.Flags().BoolVar(&syncDelete, "delete", false, "Delete destination files that do not exist at the source")
+	volumeSyncCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Show what would be transferred without changing anything")
+	volumeSyncCmd.Flags().BoolVar(&syncChecksum, "checksum", false, "Compare same-sized files by SHA-256 instead of modification time")
+	volumeSyncCmd.Flags().IntVar(&syncConcurrency, "concurrency", 4, "Number of parallel transfers")
+	volumeSyncCmd.Flags().StringVar(&syncSummaryFile, "summary", "", "Write a JSON summary of the sync to this file")
+}
+
+func mustVolumePath(p string) string {
+	p = files.NormalizePath(p)
+	if err := files.ValidatePath(p); err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+	return p
+}
+
+func runVolumeCopy(src, dst string) {
+	ctx := context.Background()
+	w := getWorkspaceClient()
+
+	var err error
+	switch {
+	case files.IsVolumePath(src) && files.IsVolumePath(dst):
+		err = copyRemoteToRemote(ctx, w, mustVolumePath(src), mustVolumePath(dst))
+	case files.IsVolumePath(src):
+		err = downloadPath(ctx, w, mustVolumePath(src), dst)
+	case files.IsVolumePath(dst):
+		err = uploadPath(ctx, w, src, mustVolumePath(dst))
+	default:
+		err = fmt.Errorf("at least one of %s and %s must be a /Volumes path", src, dst)
+	}
+	if err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+}
+
+// downloadPath downloads a file, or a directory tree when -r is set.
+func downloadPath(ctx context.Context, w *databricks.WorkspaceClient, remote, local string) error {
+	info, err := files.Stat(ctx, w, remote)
+	if err != nil {
+		return err
+	}
+	if info == nil {
+		return fmt.Errorf("%s does not exist", remote)
+	}
+	if !info.IsDirectory {
+		if st, err := os.Stat(local); err == nil && st.IsDir() {
+			local = filepath.Join(local, path.Base(remote))
+		}
+		return downloadFile(ctx, w, remote, local)
+	}
+	if !volumeRecursive {
+		return fmt.Errorf("%s is a directory (use -r)", remote)
+	}
+
+	entries, err := files.Walk(ctx, w, remote)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		rel := strings.TrimPrefix(strings.TrimPrefix(e.Path, remote), "/")
+		dst := filepath.Join(local, filepath.FromSlash(rel))
+		if _, err := os.Stat(dst); err == nil && !volumeOverwrite {
+			ui.PrintInfo(fmt.Sprintf("Skipping %s (already exists)", dst))
+			continue
+		}
+		if err := downloadFile(ctx, w, e.Path, dst); err != nil {
+			return err
+		}
+	}
+	ui.PrintSuccess(fmt.Sprintf("Downloaded %d files to %s", len(entries), local))
+	return nil
+}
+
+func downloadFile(ctx context.Context, w *databricks.WorkspaceClient, remote, local string) error {
+	if _, err := os.Stat(local); err == nil && !volumeOverwrite {
+		return fmt.Errorf("%s already exists (use --overwrite)", local)
+	}
+
+	progress, done := transferProgress(path.Base(remote))
+	err := files.DownloadFile(ctx, w, remote, local, volumeOverwrite, progress)
+	done()
+	if err != nil {
+		return err
+	}
+	ui.PrintSuccess(fmt.Sprintf("%s -> %s", remote, local))
+	return nil
+}
+
+// uploadPath uploads a file, or a directory tree when -r is set.
+func uploadPath(ctx context.Context, w *databricks.WorkspaceClient, local, remote string) error {
+	st, err := os.Stat(local)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", local, err)
+	}
+	if !st.IsDir() {
+		if info, err := files.Stat(ctx, w, remote); err == nil && info != nil && info.IsDirectory {
+			remote = path.Join(remote, filepath.Base(local))
+		}
+		return uploadFile(ctx, w, local, remote, st.Size())
+	}
+	if !volumeRecursive {
+		return fmt.Errorf("%s is a directory (use -r)", local)
+	}
+
+	count := 0
+	err = filepath.Walk(local, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(local, p)
+		if err != nil {
+			return err
+		}
+		count++
+		return uploadFile(ctx, w, p, path.Join(remote, filepath.ToSlash(rel)), fi.Size())
+	})
+	if err != nil {
+		return err
+	}
+	ui.PrintSuccess(fmt.Sprintf("Uploaded %d files to %s", count, remote))
+	return nil
+}
+
+func uploadFile(ctx context.Context, w *databricks.WorkspaceClient, local, remote string, size int64) error {
+	if !volumeOverwrite {
+		existing, err := files.Stat(ctx, w, remote)
+		if err != nil {
+			return err
+		}
+		if existing != nil && !existing.IsDirectory {
+			if existing.Size == size {
+				ui.PrintInfo(fmt.Sprintf("Skipping %s (already uploaded)", remote))
+				return nil
+			}
+			return fmt.Errorf("%s already exists with a different size (use --overwrite)", remote)
+		}
+	}
+
+	progress, done := transferProgress(filepath.Base(local))
+	err := files.UploadFile(ctx, w, local, remote, volumeOverwrite, progress)
+	done()
+	if err != nil {
+		return err
+	}
+	ui.PrintSuccess(fmt.Sprintf("%s -> %s", local, remote))
+	return nil
+}
+
+// copyRemoteToRemote streams a single file from one volume path to another.
+func copyRemoteToRemote(ctx context.Context, w *databricks.WorkspaceClient, src, dst string) error {
+	info, err := files.Stat(ctx, w, src)
+	if err != nil {
+		return err
+	}
+	if info == nil || info.IsDirectory {
+		return fmt.Errorf("%s is not a file (volume-to-volume copies are file only)", src)
+	}
+
+	body, err := files.Download(ctx, w, src, 0, 0)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	p := ui.NewProgress(path.Base(src), info.Size, 0)
+	err = files.Upload(ctx, w, dst, io.TeeReader(body, p), volumeOverwrite)
+	p.Done()
+	if err != nil {
+		return err
+	}
+	ui.PrintSuccess(fmt.Sprintf("%s -> %s", src, dst))
+	return nil
+}
+
+// transferProgress returns a files.ProgressFunc drawing a progress line and a
+// function that finishes the line once the transfer ends.
+func transferProgress(label string) (files.ProgressFunc, func()) {
+	var p *ui.Progress
+	return func(total, start int64) io.Writer {
+			p = ui.NewProgress(label, total, start)
+			return p
+		}, func() {
+			if p != nil {
+				p.Done()
+			}
+		}
+}
+
+// previewFile fetches and prints a preview of a volume file.
+func previewFile(ctx context.Context, w *databricks.WorkspaceClient, e files.Entry, maxBytes int64, maxRows int) error {
+	tables, err := files.Preview(ctx, w, e, maxBytes, maxRows)
+	if err != nil {
+		return fmt.Errorf("failed to preview %s: %w", e.Path, err)
+	}
+	for _, t := range tables {
+		if t.Title != "" {
+			ui.PrintHeader(t.Title)
+		}
+		if len(t.Rows) == 0 {
+			ui.PrintInfo("No rows.")
+			continue
+		}
+		ui.PrintTable(t.Headers, t.Rows)
+	}
+	if e.Size > maxBytes && files.DetectFormat(e.Path) != files.FormatParquet {
+		ui.PrintInfo(fmt.Sprintf("Showing the first %s of %s.", ui.FormatBytes(maxBytes), ui.FormatBytes(e.Size)))
+	}
+	return nil
+}
+
+// printSyncSummary prints the outcome of a sync. For a dry run nothing has
+// failed, so the counts are those of the planned operations.
+func printSyncSummary(s *files.SyncSummary) {
+	transferred := files.SyncUpload
+	from, to := s.Local, s.Remote
+	if s.Direction == files.SyncDown {
+		transferred = files.SyncDownload
+		from, to = s.Remote, s.Local
+	}
+
+	ui.PrintKeyValue("Sync Summary", map[string]string{
+		"Direction":   fmt.Sprintf("%s -> %s", from, to),
+		"Transferred": fmt.Sprintf("%d (%s)", s.Count(transferred), ui.FormatBytes(s.Bytes())),
+		"Deleted":     fmt.Sprintf("%d", s.Count(files.SyncDelete)),
+		"Unchanged":   fmt.Sprintf("%d", s.Unchanged),
+		"Failed":      fmt.Sprintf("%d", s.Failed()),
+		"Duration":    s.CompletedAt.Sub(s.StartedAt).Round(time.Millisecond).String(),
+	})
+	if s.DryRun {
+		ui.PrintInfo("Dry run: no files were changed.")
+	}
+}
+
+func printVolumeEntries(entries []files.Entry) {
+	var rows [][]string
+	for _, e := range entries {
+		if e.IsDirectory {
+			rows = append(rows, []string{e.Name + "/", "dir", "-", "-"})
+			continue
+		}
+		rows = append(rows, []string{e.Name, "file", ui.FormatBytes(e.Size), e.LastModified.Format("2006-01-02 15:04:05")})
+	}
+	ui.PrintTable([]string{"Name", "Type", "Size", "Modified"}, rows)
+}
@@ -0,0 +1,221 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	pkgcatalog "dbx-explore/pkg/catalog"
+	"dbx-explore/pkg/identity"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule IDs reported in findings.
+const (
+	RuleBroadAllPrivileges = "UC001"
+	RuleOrphanedGrant      = "UC002"
+	RuleInactiveOwner      = "UC003"
+	RuleManageOutsideAdmin = "UC004"
+)
+
+// Severity levels, matching SARIF result levels.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityNote    = "note"
+)
+
+// Rule describes a check the audit can run.
+type Rule struct {
+	ID          string
+	Name        string
+	Description string
+	Severity    string
+}
+
+// Rules is the full rule set, in report order.
+var Rules = []Rule{
+	{RuleBroadAllPrivileges, "all-privileges-to-broad-group", "ALL PRIVILEGES is granted to a broad group", SeverityError},
+	{RuleOrphanedGrant, "orphaned-grant", "Privileges are granted to a principal that was deleted or cannot be found in SCIM", SeverityWarning},
+	{RuleInactiveOwner, "inactive-owner", "The object is owned by a deactivated or unknown principal", SeverityWarning},
+	{RuleManageOutsideAdmin, "manage-outside-admin-group", "MANAGE is held by a principal outside the admin groups", SeverityWarning},
+}
+
+// Config tunes the rule set. It is usually loaded from a YAML file.
+type Config struct {
+	// BroadGroups are groups that must never hold ALL PRIVILEGES.
+	BroadGroups []string `yaml:"broad_groups"`
+	// AdminGroups are the only principals allowed to hold MANAGE.
+	AdminGroups []string `yaml:"admin_groups"`
+	// Disabled lists rule IDs or names to skip.
+	Disabled []string `yaml:"disabled"`
+}
+
+// DefaultConfig returns the configuration used when no file is given.
+func DefaultConfig() Config {
+	return Config{
+		BroadGroups: []string{"account users", "users"},
+		AdminGroups: []string{"admins"},
+	}
+}
+
+// LoadConfig reads a rule configuration file. Fields missing from the file keep
+// their default values.
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read rules file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse rules file: %w", err)
+	}
+	return cfg, nil
+}
+
+func (c Config) enabled(r Rule) bool {
+	for _, d := range c.Disabled {
+		if strings.EqualFold(d, r.ID) || strings.EqualFold(d, r.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+// Finding is a single rule violation.
+type Finding struct {
+	RuleID    string               `json:"rule_id"`
+	Severity  string               `json:"severity"`
+	Securable pkgcatalog.Securable `json:"securable"`
+	Principal string               `json:"principal"`
+	Message   string               `json:"message"`
+}
+
+// BuiltinPrincipals are account and workspace groups that always exist but
+// cannot be found through workspace SCIM.
+var BuiltinPrincipals = []string{"account users", "users", "admins"}
+
+// Lookup resolves a principal name, returning nil if it does not exist.
+type Lookup func(ctx context.Context, name string) (*identity.Principal, error)
+
+// Auditor evaluates the configured rules against securables and their grants.
+type Auditor struct {
+	cfg    Config
+	lookup Lookup
+	rules  map[string]Rule
+}
+
+// NewAuditor creates an Auditor. lookup is typically identity.Directory.Lookup.
+func NewAuditor(cfg Config, lookup Lookup) *Auditor {
+	rules := make(map[string]Rule)
+	for _, r := range Rules {
+		if cfg.enabled(r) {
+			rules[r.ID] = r
+		}
+	}
+	return &Auditor{cfg: cfg, lookup: lookup, rules: rules}
+}
+
+// Evaluate checks one securable and its direct grants.
+func (a *Auditor) Evaluate(ctx context.Context, s pkgcatalog.Securable, grants []catalog.PrivilegeAssignment) ([]Finding, error) {
+	var findings []Finding
+
+	if _, ok := a.rules[RuleInactiveOwner]; ok && s.Owner != "" {
+		p, err := a.resolve(ctx, s.Owner)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case p == nil:
+			findings = append(findings, a.finding(RuleInactiveOwner, s, s.Owner, fmt.Sprintf("%s is owned by %s, an unknown principal", s.FullName, s.Owner)))
+		case !p.Active:
+			findings = append(findings, a.finding(RuleInactiveOwner, s, s.Owner, fmt.Sprintf("%s is owned by deactivated %s %s", s.FullName, p.Kind, s.Owner)))
+		}
+	}
+
+	for _, pa := range grants {
+		if pa.Principal == "" {
+			// Grants to deleted principals come back with only a principal ID.
+			if _, ok := a.rules[RuleOrphanedGrant]; ok {
+				findings = append(findings, a.finding(RuleOrphanedGrant, s, "", fmt.Sprintf("%s has grants to a deleted principal", s.FullName)))
+			}
+			continue
+		}
+
+		if _, ok := a.rules[RuleBroadAllPrivileges]; ok && containsFold(a.cfg.BroadGroups, pa.Principal) && hasPrivilege(pa, catalog.PrivilegeAllPrivileges) {
+			findings = append(findings, a.finding(RuleBroadAllPrivileges, s, pa.Principal, fmt.Sprintf("ALL PRIVILEGES on %s is granted to %s", s.FullName, pa.Principal)))
+		}
+
+		_, checkOrphans := a.rules[RuleOrphanedGrant]
+		_, checkManage := a.rules[RuleManageOutsideAdmin]
+		needsManageCheck := checkManage && hasPrivilege(pa, catalog.PrivilegeManage) && !containsFold(a.cfg.AdminGroups, pa.Principal)
+		if !checkOrphans && !needsManageCheck {
+			continue
+		}
+
+		p, err := a.resolve(ctx, pa.Principal)
+		if err != nil {
+			return nil, err
+		}
+		if p == nil {
+			if checkOrphans {
+				findings = append(findings, a.finding(RuleOrphanedGrant, s, pa.Principal, fmt.Sprintf("%s grants %s to %s, an unknown principal", s.FullName, joinPrivileges(pa.Privileges), pa.Principal)))
+			}
+			continue
+		}
+		if needsManageCheck && !a.inAdminGroup(p) {
+			findings = append(findings, a.finding(RuleManageOutsideAdmin, s, pa.Principal, fmt.Sprintf("MANAGE on %s is held by %s %s outside the admin groups", s.FullName, p.Kind, pa.Principal)))
+		}
+	}
+	return findings, nil
+}
+
+// resolve looks up a principal, treating the BuiltinPrincipals as active groups.
+func (a *Auditor) resolve(ctx context.Context, name string) (*identity.Principal, error) {
+	if containsFold(BuiltinPrincipals, name) {
+		return &identity.Principal{Name: name, Kind: identity.KindGroup, Active: true}, nil
+	}
+	return a.lookup(ctx, name)
+}
+
+func (a *Auditor) inAdminGroup(p *identity.Principal) bool {
+	for _, g := range p.Groups {
+		if containsFold(a.cfg.AdminGroups, g) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Auditor) finding(ruleID string, s pkgcatalog.Securable, principal, msg string) Finding {
+	return Finding{RuleID: ruleID, Severity: a.rules[ruleID].Severity, Securable: s, Principal: principal, Message: msg}
+}
+
+func hasPrivilege(pa catalog.PrivilegeAssignment, priv catalog.Privilege) bool {
+	for _, p := range pa.Privileges {
+		if p == priv {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPrivileges(privs []catalog.Privilege) string {
+	names := make([]string, len(privs))
+	for i, p := range privs {
+		names[i] = string(p)
+	}
+	return strings.Join(names, ", ")
+}
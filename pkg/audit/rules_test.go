@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	pkgcatalog "dbx-explore/pkg/catalog"
+	"dbx-explore/pkg/identity"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+func TestEvaluate(t *testing.T) {
+	principals := map[string]*identity.Principal{
+		"jane@example.com": {Name: "jane@example.com", Kind: identity.KindUser, Active: true, Groups: []string{"admins"}},
+		"bob@example.com":  {Name: "bob@example.com", Kind: identity.KindUser, Active: true, Groups: []string{"analysts"}},
+		"old@example.com":  {Name: "old@example.com", Kind: identity.KindUser, Active: false},
+		"analysts":         {Name: "analysts", Kind: identity.KindGroup, Active: true},
+	}
+	lookup := func(ctx context.Context, name string) (*identity.Principal, error) {
+		if name == "account users" || name == "users" || name == "admins" {
+			t.Errorf("built-in principal %s looked up in SCIM", name)
+		}
+		return principals[name], nil
+	}
+	grant := func(principal string, privs ...catalog.Privilege) catalog.PrivilegeAssignment {
+		return catalog.PrivilegeAssignment{Principal: principal, Privileges: privs}
+	}
+
+	tests := []struct {
+		name   string
+		owner  string
+		grants []catalog.PrivilegeAssignment
+		want   []string
+	}{
+		{
+			name:   "clean",
+			owner:  "account users",
+			grants: []catalog.PrivilegeAssignment{grant("account users", catalog.PrivilegeSelect), grant("analysts", catalog.PrivilegeSelect), grant("jane@example.com", catalog.PrivilegeManage)},
+		},
+		{
+			name:   "UC001 broad group",
+			owner:  "analysts",
+			grants: []catalog.PrivilegeAssignment{grant("Account Users", catalog.PrivilegeAllPrivileges), grant("analysts", catalog.PrivilegeAllPrivileges)},
+			want:   []string{RuleBroadAllPrivileges},
+		},
+		{
+			name:   "UC002 unknown and deleted",
+			owner:  "analysts",
+			grants: []catalog.PrivilegeAssignment{grant("ghost", catalog.PrivilegeSelect), {Privileges: []catalog.Privilege{catalog.PrivilegeSelect}}},
+			want:   []string{RuleOrphanedGrant, RuleOrphanedGrant},
+		},
+		{
+			name:  "UC003 deactivated owner",
+			owner: "old@example.com",
+			want:  []string{RuleInactiveOwner},
+		},
+		{
+			name:  "UC003 unknown owner",
+			owner: "ghost@example.com",
+			want:  []string{RuleInactiveOwner},
+		},
+		{
+			name:   "UC004 manage outside admins",
+			owner:  "admins",
+			grants: []catalog.PrivilegeAssignment{grant("bob@example.com", catalog.PrivilegeManage), grant("users", catalog.PrivilegeManage), grant("admins", catalog.PrivilegeManage)},
+			want:   []string{RuleManageOutsideAdmin, RuleManageOutsideAdmin},
+		},
+	}
+	a := NewAuditor(DefaultConfig(), lookup)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := pkgcatalog.Securable{Type: "table", FullName: "main.sales.orders", Owner: tt.owner}
+			findings, err := a.Evaluate(context.Background(), s, tt.grants)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, f := range findings {
+				got = append(got, f.RuleID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rules = %v, want %v (%+v)", got, tt.want, findings)
+			}
+		})
+	}
+}
+
+func TestEvaluateDisabled(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Disabled = []string{"UC002", "inactive-owner"}
+	a := NewAuditor(cfg, func(ctx context.Context, name string) (*identity.Principal, error) { return nil, nil })
+	s := pkgcatalog.Securable{Type: "table", FullName: "main.sales.orders", Owner: "ghost@example.com"}
+	findings, err := a.Evaluate(context.Background(), s, []catalog.PrivilegeAssignment{{Principal: "ghost", Privileges: []catalog.Privilege{catalog.PrivilegeSelect}}})
+	if err != nil || len(findings) != 0 {
+		t.Errorf("Evaluate = %+v, %v; want no findings", findings, err)
+	}
+}
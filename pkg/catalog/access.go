@@ -0,0 +1,65 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+)
+
+// AccessEntry is one privilege a principal effectively holds on a securable.
+type AccessEntry struct {
+	Securable Securable `json:"securable"`
+	Privilege string    `json:"privilege"`
+	// Via is the principal the privilege is granted to: the principal itself or
+	// one of the groups it belongs to.
+	Via string `json:"via"`
+	// InheritedFrom names the parent securable the privilege comes from, e.g.
+	// "CATALOG main". It is empty for privileges granted on the object itself.
+	InheritedFrom string `json:"inherited_from,omitempty"`
+}
+
+// Source describes where the privilege comes from, for grouping reports.
+func (e AccessEntry) Source() string {
+	if e.InheritedFrom == "" {
+		return "Granted on object"
+	}
+	return "Inherited from " + e.InheritedFrom
+}
+
+// CollectAccess crawls the effective permissions of every securable under each
+// scope and keeps the privileges held by any of the given principals. The
+// principals set is keyed by lower-cased name, as grants match principals
+// case-insensitively.
+func CollectAccess(ctx context.Context, w *databricks.WorkspaceClient, scopes []string, principals map[string]bool, progress func(Securable)) ([]AccessEntry, error) {
+	var entries []AccessEntry
+	for _, scope := range scopes {
+		err := WalkSecurables(ctx, w, scope, func(s Securable) error {
+			if progress != nil {
+				progress(s)
+			}
+			perms, err := GetEffectivePermissions(ctx, w, s.Type, s.FullName)
+			if err != nil {
+				return fmt.Errorf("failed to get permissions for %s: %w", s.FullName, err)
+			}
+			for _, pa := range perms.PrivilegeAssignments {
+				if !principals[strings.ToLower(pa.Principal)] {
+					continue
+				}
+				for _, priv := range pa.Privileges {
+					entry := AccessEntry{Securable: s, Privilege: string(priv.Privilege), Via: pa.Principal}
+					if priv.InheritedFromType != "" {
+						entry.InheritedFrom = fmt.Sprintf("%s %s", priv.InheritedFromType, priv.InheritedFromName)
+					}
+					entries = append(entries, entry)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,59 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+// bindingSecurableType is the bindings API name for catalogs.
+const bindingSecurableType = "catalog"
+
+// GetWorkspaceBindings lists the workspaces an isolated catalog is bound to.
+func GetWorkspaceBindings(ctx context.Context, w *databricks.WorkspaceClient, catalogName string) ([]catalog.WorkspaceBinding, error) {
+	bindings, err := w.WorkspaceBindings.GetBindingsAll(ctx, catalog.GetBindingsRequest{SecurableType: bindingSecurableType, SecurableName: catalogName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace bindings of %s: %w", catalogName, err)
+	}
+	return bindings, nil
+}
+
+// BindWorkspace binds a catalog to a workspace, read-only or read/write.
+func BindWorkspace(ctx context.Context, w *databricks.WorkspaceClient, catalogName string, workspaceID int64, readOnly bool) error {
+	binding := catalog.WorkspaceBinding{WorkspaceId: workspaceID, BindingType: catalog.WorkspaceBindingBindingTypeBindingTypeReadWrite}
+	if readOnly {
+		binding.BindingType = catalog.WorkspaceBindingBindingTypeBindingTypeReadOnly
+	}
+	_, err := w.WorkspaceBindings.UpdateBindings(ctx, catalog.UpdateWorkspaceBindingsParameters{
+		SecurableType: bindingSecurableType,
+		SecurableName: catalogName,
+		Add:           []catalog.WorkspaceBinding{binding},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to bind %s to workspace %d: %w", catalogName, workspaceID, err)
+	}
+	return nil
+}
+
+// UnbindWorkspace removes a workspace binding from a catalog.
+func UnbindWorkspace(ctx context.Context, w *databricks.WorkspaceClient, catalogName string, binding catalog.WorkspaceBinding) error {
+	_, err := w.WorkspaceBindings.UpdateBindings(ctx, catalog.UpdateWorkspaceBindingsParameters{
+		SecurableType: bindingSecurableType,
+		SecurableName: catalogName,
+		Remove:        []catalog.WorkspaceBinding{binding},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unbind %s from workspace %d: %w", catalogName, binding.WorkspaceId, err)
+	}
+	return nil
+}
+
+// BindingLabel describes a binding type.
+func BindingLabel(t catalog.WorkspaceBindingBindingType) string {
+	if t == catalog.WorkspaceBindingBindingTypeBindingTypeReadOnly {
+		return "Read Only"
+	}
+	return "Read/Write"
+}
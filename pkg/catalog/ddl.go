@@ -0,0 +1,267 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+const ucAPI = "/api/2.1/unity-catalog"
+
+// Operation is a create, rename or drop call that can be shown as the exact
+// REST request before it is sent.
+type Operation struct {
+	Summary string
+	Method  string
+	Path    string
+	// Body is the JSON request body, nil for requests without one.
+	Body interface{}
+	// Command holds the arguments of the equivalent databricks CLI command.
+	Command []string
+	apply   func(ctx context.Context, w *databricks.WorkspaceClient) error
+}
+
+// Request formats the operation as an HTTP request line and JSON body.
+func (o *Operation) Request() string {
+	line := o.Method + " " + o.Path
+	if o.Body == nil {
+		return line
+	}
+	body, err := json.MarshalIndent(o.Body, "", "  ")
+	if err != nil {
+		return line
+	}
+	return line + "\n" + string(body)
+}
+
+// CLI formats the equivalent databricks CLI command for a POSIX shell.
+func (o *Operation) CLI() string {
+	parts := []string{"databricks"}
+	for _, a := range o.Command {
+		parts = append(parts, shellQuote(a))
+	}
+	return strings.Join(parts, " ")
+}
+
+func shellQuote(s string) string {
+	safe := s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=@,", r))
+	}) < 0
+	if safe {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// jsonCommand is a CLI create command taking the whole request as --json.
+func jsonCommand(service string, body interface{}) []string {
+	data, _ := json.Marshal(body)
+	return []string{service, "create", "--json", string(data)}
+}
+
+// Apply sends the request.
+func (o *Operation) Apply(ctx context.Context, w *databricks.WorkspaceClient) error {
+	if err := o.apply(ctx, w); err != nil {
+		return fmt.Errorf("%s failed: %w", strings.ToLower(o.Summary[:1])+o.Summary[1:], err)
+	}
+	return nil
+}
+
+// CreateCatalog creates a managed catalog, a foreign catalog (ConnectionName)
+// or a catalog from a Delta Sharing share (ProviderName and ShareName).
+func CreateCatalog(req catalog.CreateCatalog) (*Operation, error) {
+	if req.Name == "" {
+		return nil, errors.New("catalog name is required")
+	}
+	foreign := req.ConnectionName != ""
+	shared := req.ProviderName != "" || req.ShareName != ""
+	switch {
+	case foreign && shared:
+		return nil, errors.New("a catalog is either foreign (connection) or shared (provider and share), not both")
+	case shared && (req.ProviderName == "" || req.ShareName == ""):
+		return nil, errors.New("a shared catalog needs both a provider and a share")
+	case (foreign || shared) && req.StorageRoot != "":
+		return nil, errors.New("only managed catalogs have a storage root")
+	case !foreign && len(req.Options) > 0:
+		return nil, errors.New("options are only used by foreign catalogs")
+	}
+
+	kind := "managed"
+	if foreign {
+		kind = "foreign"
+	} else if shared {
+		kind = "shared"
+	}
+	return &Operation{
+		Summary: fmt.Sprintf("Create %s catalog %s", kind, req.Name),
+		Method:  http.MethodPost,
+		Path:    ucAPI + "/catalogs",
+		Body:    req,
+		Command: jsonCommand("catalogs", req),
+		apply: func(ctx context.Context, w *databricks.WorkspaceClient) error {
+			_, err := w.Catalogs.Create(ctx, req)
+			return err
+		},
+	}, nil
+}
+
+// CreateSchema creates a schema, with a managed location when StorageRoot is
+// set.
+func CreateSchema(req catalog.CreateSchema) (*Operation, error) {
+	if req.CatalogName == "" || req.Name == "" {
+		return nil, errors.New("catalog and schema name are required")
+	}
+	return &Operation{
+		Summary: fmt.Sprintf("Create schema %s.%s", req.CatalogName, req.Name),
+		Method:  http.MethodPost,
+		Path:    ucAPI + "/schemas",
+		Body:    req,
+		Command: jsonCommand("schemas", req),
+		apply: func(ctx context.Context, w *databricks.WorkspaceClient) error {
+			_, err := w.Schemas.Create(ctx, req)
+			return err
+		},
+	}, nil
+}
+
+// CreateVolume creates a managed volume, or an external one at
+// StorageLocation.
+func CreateVolume(req catalog.CreateVolumeRequestContent) (*Operation, error) {
+	if req.CatalogName == "" || req.SchemaName == "" || req.Name == "" {
+		return nil, errors.New("catalog, schema and volume name are required")
+	}
+	switch req.VolumeType {
+	case catalog.VolumeTypeExternal:
+		if req.StorageLocation == "" {
+			return nil, errors.New("an external volume needs a storage location")
+		}
+	case catalog.VolumeTypeManaged:
+		if req.StorageLocation != "" {
+			return nil, errors.New("a managed volume has no storage location")
+		}
+	default:
+		return nil, fmt.Errorf("unknown volume type %q", req.VolumeType)
+	}
+	return &Operation{
+		Summary: fmt.Sprintf("Create %s volume %s.%s.%s", strings.ToLower(string(req.VolumeType)), req.CatalogName, req.SchemaName, req.Name),
+		Method:  http.MethodPost,
+		Path:    ucAPI + "/volumes",
+		Body:    req,
+		Command: jsonCommand("volumes", req),
+		apply: func(ctx context.Context, w *databricks.WorkspaceClient) error {
+			_, err := w.Volumes.Create(ctx, req)
+			return err
+		},
+	}, nil
+}
+
+// Rename renames a catalog, schema, volume or registered model. newName is the
+// new last part of the name.
+func Rename(kind ObjectKind, fullName, newName string) (*Operation, error) {
+	if newName == "" || strings.Contains(newName, ".") {
+		return nil, fmt.Errorf("invalid new name %q: give the new %s name without its parents", newName, kind)
+	}
+	op := &Operation{Summary: fmt.Sprintf("Rename %s %s to %s", kind, fullName, newName), Method: http.MethodPatch}
+	switch kind {
+	case KindCatalog:
+		req := catalog.UpdateCatalog{Name: fullName, NewName: newName}
+		op.Path, op.Body = ucAPI+"/catalogs/"+fullName, req
+		op.Command = []string{"catalogs", "update", fullName, "--new-name", newName}
+		op.apply = func(ctx context.Context, w *databricks.WorkspaceClient) error {
+			_, err := w.Catalogs.Update(ctx, req)
+			return err
+		}
+	case KindSchema:
+		req := catalog.UpdateSchema{FullName: fullName, NewName: newName}
+		op.Path, op.Body = ucAPI+"/schemas/"+fullName, req
+		op.Command = []string{"schemas", "update", fullName, "--new-name", newName}
+		op.apply = func(ctx context.Context, w *databricks.WorkspaceClient) error {
+			_, err := w.Schemas.Update(ctx, req)
+			return err
+		}
+	case KindVolume:
+		req := catalog.UpdateVolumeRequestContent{Name: fullName, NewName: newName}
+		op.Path, op.Body = ucAPI+"/volumes/"+fullName, req
+		op.Command = []string{"volumes", "update", fullName, "--new-name", newName}
+		op.apply = func(ctx context.Context, w *databricks.WorkspaceClient) error {
+			_, err := w.Volumes.Update(ctx, req)
+			return err
+		}
+	case KindModel:
+		req := catalog.UpdateRegisteredModelRequest{FullName: fullName, NewName: newName}
+		op.Path, op.Body = ucAPI+"/models/"+fullName, req
+		op.Command = []string{"registered-models", "update", fullName, "--new-name", newName}
+		op.apply = func(ctx context.Context, w *databricks.WorkspaceClient) error {
+			_, err := w.RegisteredModels.Update(ctx, req)
+			return err
+		}
+	case KindTable, KindView:
+		return nil, fmt.Errorf("%ss cannot be renamed through the API; use ALTER %s ... RENAME TO", kind, strings.ToUpper(string(kind)))
+	default:
+		return nil, fmt.Errorf("renaming %s objects is not supported", kind)
+	}
+	return op, nil
+}
+
+// Drop deletes an object. force also drops a non-empty catalog or schema, or
+// a function that other objects depend on.
+func Drop(kind ObjectKind, fullName string, force bool) (*Operation, error) {
+	op := &Operation{Summary: fmt.Sprintf("Drop %s %s", kind, fullName), Method: http.MethodDelete}
+	query, forceFlag := "", []string(nil)
+	if force {
+		query, forceFlag = "?force=true", []string{"--force"}
+	}
+	switch kind {
+	case KindCatalog:
+		op.Path = ucAPI + "/catalogs/" + fullName + query
+		op.Command = append([]string{"catalogs", "delete", fullName}, forceFlag...)
+		op.apply = func(ctx context.Context, w *databricks.WorkspaceClient) error {
+			return w.Catalogs.Delete(ctx, catalog.DeleteCatalogRequest{Name: fullName, Force: force})
+		}
+	case KindSchema:
+		op.Path = ucAPI + "/schemas/" + fullName + query
+		op.Command = append([]string{"schemas", "delete", fullName}, forceFlag...)
+		op.apply = func(ctx context.Context, w *databricks.WorkspaceClient) error {
+			return w.Schemas.Delete(ctx, catalog.DeleteSchemaRequest{FullName: fullName, Force: force})
+		}
+	case KindFunction:
+		op.Path = ucAPI + "/functions/" + fullName + query
+		op.Command = append([]string{"functions", "delete", fullName}, forceFlag...)
+		op.apply = func(ctx context.Context, w *databricks.WorkspaceClient) error {
+			return w.Functions.Delete(ctx, catalog.DeleteFunctionRequest{Name: fullName, Force: force})
+		}
+	case KindTable, KindView, KindVolume, KindModel:
+		if force {
+			return nil, fmt.Errorf("--force does not apply to a %s", kind)
+		}
+		switch kind {
+		case KindVolume:
+			op.Path = ucAPI + "/volumes/" + fullName
+			op.Command = []string{"volumes", "delete", fullName}
+			op.apply = func(ctx context.Context, w *databricks.WorkspaceClient) error {
+				return w.Volumes.Delete(ctx, catalog.DeleteVolumeRequest{Name: fullName})
+			}
+		case KindModel:
+			op.Path = ucAPI + "/models/" + fullName
+			op.Command = []string{"registered-models", "delete", fullName}
+			op.apply = func(ctx context.Context, w *databricks.WorkspaceClient) error {
+				return w.RegisteredModels.Delete(ctx, catalog.DeleteRegisteredModelRequest{FullName: fullName})
+			}
+		default:
+			op.Path = ucAPI + "/tables/" + fullName
+			op.Command = []string{"tables", "delete", fullName}
+			op.apply = func(ctx context.Context, w *databricks.WorkspaceClient) error {
+				return w.Tables.Delete(ctx, catalog.DeleteTableRequest{FullName: fullName})
+			}
+		}
+	default:
+		return nil, fmt.Errorf("dropping %s objects is not supported", kind)
+	}
+	return op, nil
+}
@@ -0,0 +1,104 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+func TestCreateCatalog(t *testing.T) {
+	op, err := CreateCatalog(catalog.CreateCatalog{Name: "pg", ConnectionName: "pg_conn", Options: map[string]string{"database": "shop"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `POST /api/2.1/unity-catalog/catalogs
+{
+  "connection_name": "pg_conn",
+  "name": "pg",
+  "options": {
+    "database": "shop"
+  }
+}`
+	if got := op.Request(); got != want {
+		t.Errorf("Request() =\n%s\nwant\n%s", got, want)
+	}
+	if op.Summary != "Create foreign catalog pg" {
+		t.Errorf("Summary = %q", op.Summary)
+	}
+	if want := `databricks catalogs create --json '{"connection_name":"pg_conn","name":"pg","options":{"database":"shop"}}'`; op.CLI() != want {
+		t.Errorf("CLI() = %s\nwant %s", op.CLI(), want)
+	}
+
+	for _, bad := range []catalog.CreateCatalog{
+		{},
+		{Name: "x", ConnectionName: "c", ProviderName: "p", ShareName: "s"},
+		{Name: "x", ProviderName: "p"},
+		{Name: "x", ProviderName: "p", ShareName: "s", StorageRoot: "s3://b"},
+		{Name: "x", Options: map[string]string{"a": "b"}},
+	} {
+		if _, err := CreateCatalog(bad); err == nil {
+			t.Errorf("expected an error for %+v", bad)
+		}
+	}
+}
+
+func TestCreateVolume(t *testing.T) {
+	req := catalog.CreateVolumeRequestContent{CatalogName: "main", SchemaName: "raw", Name: "landing", VolumeType: catalog.VolumeTypeExternal}
+	if _, err := CreateVolume(req); err == nil {
+		t.Error("expected an error for an external volume without a location")
+	}
+	req.StorageLocation = "s3://bucket/landing"
+	op, err := CreateVolume(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.Summary != "Create external volume main.raw.landing" || !strings.Contains(op.Request(), `"volume_type": "EXTERNAL"`) {
+		t.Errorf("unexpected operation %q:\n%s", op.Summary, op.Request())
+	}
+}
+
+func TestCLIQuoting(t *testing.T) {
+	op, err := CreateSchema(catalog.CreateSchema{CatalogName: "main", Name: "raw", Comment: "Bob's data, raw"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `databricks schemas create --json '{"catalog_name":"main","comment":"Bob'\''s data, raw","name":"raw"}'`
+	if op.CLI() != want {
+		t.Errorf("CLI() = %s\nwant %s", op.CLI(), want)
+	}
+}
+
+func TestRenameAndDrop(t *testing.T) {
+	op, err := Rename(KindSchema, "main.sales", "sales_v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "PATCH /api/2.1/unity-catalog/schemas/main.sales\n{\n  \"new_name\": \"sales_v2\"\n}"; op.Request() != want {
+		t.Errorf("Request() =\n%s\nwant\n%s", op.Request(), want)
+	}
+	if op.CLI() != "databricks schemas update main.sales --new-name sales_v2" {
+		t.Errorf("CLI() = %s", op.CLI())
+	}
+	if _, err := Rename(KindTable, "main.sales.orders", "orders_v2"); err == nil {
+		t.Error("expected an error renaming a table")
+	}
+	if _, err := Rename(KindSchema, "main.sales", "main.sales_v2"); err == nil {
+		t.Error("expected an error for a dotted new name")
+	}
+
+	op, err = Drop(KindCatalog, "dev", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.Request() != "DELETE /api/2.1/unity-catalog/catalogs/dev?force=true" || op.CLI() != "databricks catalogs delete dev --force" {
+		t.Errorf("Request() = %s, CLI() = %s", op.Request(), op.CLI())
+	}
+	op, _ = Drop(KindView, "main.sales.v", false)
+	if op.Request() != "DELETE /api/2.1/unity-catalog/tables/main.sales.v" || op.CLI() != "databricks tables delete main.sales.v" {
+		t.Errorf("Request() = %s, CLI() = %s", op.Request(), op.CLI())
+	}
+	if _, err := Drop(KindTable, "main.sales.orders", true); err == nil {
+		t.Error("expected an error for --force on a table")
+	}
+}
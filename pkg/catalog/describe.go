@@ -0,0 +1,139 @@
+package catalog
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+// clusteringProperty holds liquid clustering columns as a JSON list of field
+// paths, e.g. [["region"],["address","city"]].
+const clusteringProperty = "clusteringColumns"
+
+// deltaSettings are the table properties shown in the Delta settings section
+// rather than with the other properties, in display order.
+var deltaSettings = []struct{ Key, Label string }{
+	{"delta.enableDeletionVectors", "Deletion Vectors"},
+	{"delta.enableChangeDataFeed", "Change Data Feed"},
+	{"delta.enableRowTracking", "Row Tracking"},
+	{"delta.columnMapping.mode", "Column Mapping"},
+	{"delta.minReaderVersion", "Min Reader Version"},
+	{"delta.minWriterVersion", "Min Writer Version"},
+	{"delta.checkpointPolicy", "Checkpoint Policy"},
+	{"delta.logRetentionDuration", "Log Retention"},
+	{"delta.deletedFileRetentionDuration", "Deleted File Retention"},
+}
+
+// Setting is a labelled value in display order.
+type Setting struct {
+	Name  string
+	Value string
+}
+
+// ColumnMaskInfo is the mask applied to one column.
+type ColumnMaskInfo struct {
+	Column           string
+	FunctionName     string
+	UsingColumnNames []string
+}
+
+// TableDescription groups everything Unity Catalog knows about a table into
+// the sections of a describe view.
+type TableDescription struct {
+	Info              catalog.TableInfo
+	PartitionColumns  []string
+	ClusteringColumns []string
+	PrimaryKey        *catalog.PrimaryKeyConstraint
+	ForeignKeys       []catalog.ForeignKeyConstraint
+	RowFilter         *catalog.TableRowFilter
+	ColumnMasks       []ColumnMaskInfo
+	DeltaSettings     []Setting
+	Properties        []Setting
+}
+
+// DescribeTable splits a TableInfo into describe sections.
+func DescribeTable(t catalog.TableInfo) *TableDescription {
+	d := &TableDescription{Info: t, RowFilter: t.RowFilter}
+
+	type partition struct {
+		name  string
+		index int
+	}
+	var partitions []partition
+	for _, c := range t.Columns {
+		if c.PartitionIndex > 0 || hasField(c.ForceSendFields, "PartitionIndex") {
+			partitions = append(partitions, partition{c.Name, c.PartitionIndex})
+		}
+		if c.Mask != nil && c.Mask.FunctionName != "" {
+			d.ColumnMasks = append(d.ColumnMasks, ColumnMaskInfo{Column: c.Name, FunctionName: c.Mask.FunctionName, UsingColumnNames: c.Mask.UsingColumnNames})
+		}
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i].index < partitions[j].index })
+	for _, p := range partitions {
+		d.PartitionColumns = append(d.PartitionColumns, p.name)
+	}
+
+	for _, c := range t.TableConstraints {
+		switch {
+		case c.PrimaryKeyConstraint != nil:
+			d.PrimaryKey = c.PrimaryKeyConstraint
+		case c.ForeignKeyConstraint != nil:
+			d.ForeignKeys = append(d.ForeignKeys, *c.ForeignKeyConstraint)
+		}
+	}
+
+	d.ClusteringColumns = ParseClusteringColumns(t.Properties[clusteringProperty])
+
+	shown := map[string]bool{clusteringProperty: true}
+	for _, s := range deltaSettings {
+		shown[s.Key] = true
+		if v, ok := t.Properties[s.Key]; ok {
+			d.DeltaSettings = append(d.DeltaSettings, Setting{s.Label, v})
+		}
+	}
+	if t.EffectivePredictiveOptimizationFlag != nil {
+		d.DeltaSettings = append(d.DeltaSettings, Setting{"Predictive Optimization", string(t.EffectivePredictiveOptimizationFlag.Value)})
+	} else if t.EnablePredictiveOptimization != "" {
+		d.DeltaSettings = append(d.DeltaSettings, Setting{"Predictive Optimization", string(t.EnablePredictiveOptimization)})
+	}
+
+	keys := make([]string, 0, len(t.Properties))
+	for k := range t.Properties {
+		if !shown[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		d.Properties = append(d.Properties, Setting{k, t.Properties[k]})
+	}
+	return d
+}
+
+// ParseClusteringColumns decodes the clusteringColumns table property into
+// dotted column paths. Malformed values are returned as-is.
+func ParseClusteringColumns(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var paths [][]string
+	if err := json.Unmarshal([]byte(value), &paths); err != nil {
+		return []string{value}
+	}
+	cols := make([]string, len(paths))
+	for i, p := range paths {
+		cols[i] = strings.Join(p, ".")
+	}
+	return cols
+}
+
+func hasField(fields []string, name string) bool {
+	for _, f := range fields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
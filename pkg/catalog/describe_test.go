@@ -0,0 +1,71 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+func TestParseClusteringColumns(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"", nil},
+		{`[["region"]]`, []string{"region"}},
+		{`[["region"],["address","city"]]`, []string{"region", "address.city"}},
+		{"not json", []string{"not json"}},
+	}
+	for _, tt := range tests {
+		if got := ParseClusteringColumns(tt.value); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseClusteringColumns(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestDescribeTable(t *testing.T) {
+	info := catalog.TableInfo{
+		FullName: "main.sales.orders",
+		Columns: []catalog.ColumnInfo{
+			{Name: "id"},
+			{Name: "day", PartitionIndex: 1},
+			{Name: "region", PartitionIndex: 0, ForceSendFields: []string{"PartitionIndex"}},
+			{Name: "email", Mask: &catalog.ColumnMask{FunctionName: "main.sec.mask_email"}},
+		},
+		TableConstraints: []catalog.TableConstraint{
+			{PrimaryKeyConstraint: &catalog.PrimaryKeyConstraint{Name: "orders_pk", ChildColumns: []string{"id"}}},
+			{ForeignKeyConstraint: &catalog.ForeignKeyConstraint{Name: "orders_customer_fk", ChildColumns: []string{"customer_id"}, ParentTable: "main.sales.customers", ParentColumns: []string{"id"}}},
+		},
+		Properties: map[string]string{
+			"clusteringColumns":           `[["customer_id"]]`,
+			"delta.enableDeletionVectors": "true",
+			"delta.minReaderVersion":      "3",
+			"owner.team":                  "sales",
+		},
+	}
+
+	d := DescribeTable(info)
+	if want := []string{"region", "day"}; !reflect.DeepEqual(d.PartitionColumns, want) {
+		t.Errorf("PartitionColumns = %v, want %v", d.PartitionColumns, want)
+	}
+	if want := []string{"customer_id"}; !reflect.DeepEqual(d.ClusteringColumns, want) {
+		t.Errorf("ClusteringColumns = %v, want %v", d.ClusteringColumns, want)
+	}
+	if d.PrimaryKey == nil || d.PrimaryKey.Name != "orders_pk" {
+		t.Errorf("PrimaryKey = %+v", d.PrimaryKey)
+	}
+	if len(d.ForeignKeys) != 1 || d.ForeignKeys[0].ParentTable != "main.sales.customers" {
+		t.Errorf("ForeignKeys = %+v", d.ForeignKeys)
+	}
+	if len(d.ColumnMasks) != 1 || d.ColumnMasks[0].Column != "email" {
+		t.Errorf("ColumnMasks = %+v", d.ColumnMasks)
+	}
+	wantDelta := []Setting{{"Deletion Vectors", "true"}, {"Min Reader Version", "3"}}
+	if !reflect.DeepEqual(d.DeltaSettings, wantDelta) {
+		t.Errorf("DeltaSettings = %v, want %v", d.DeltaSettings, wantDelta)
+	}
+	if want := []Setting{{"owner.team", "sales"}}; !reflect.DeepEqual(d.Properties, want) {
+		t.Errorf("Properties = %v, want %v", d.Properties, want)
+	}
+}
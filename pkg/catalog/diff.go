@@ -0,0 +1,284 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Change is one difference between two models: an add, remove or replace that
+// turns the left model into the right one. Paths address catalogs, schemas,
+// tables, columns, properties and grants by name, e.g.
+// /tables/orders/columns/id/type. The ops borrow JSON Patch names, but the
+// paths do not index the serialized model, so a list of changes is not an
+// RFC 6902 patch.
+type Change struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+	Old   interface{} `json:"-"`
+}
+
+// DiffModels compares two models. Two schemas are compared directly, even if
+// their names differ; two catalogs are compared schema by schema.
+func DiffModels(left, right *ScopeModel) ([]Change, error) {
+	if left.IsCatalog() != right.IsCatalog() {
+		return nil, fmt.Errorf("cannot compare %s with %s: both sides must be catalogs or both schemas", left.Scope, right.Scope)
+	}
+
+	var d differ
+	if !left.IsCatalog() {
+		if len(left.Schemas) == 1 && len(right.Schemas) == 1 {
+			d.schema("", left.Schemas[0], right.Schemas[0])
+		}
+		return d.changes, nil
+	}
+
+	d.schemas("", left.Schemas, right.Schemas)
+	return d.changes, nil
+}
+
+type differ struct {
+	changes []Change
+}
+
+func (d *differ) add(op, path string, value, old interface{}) {
+	d.changes = append(d.changes, Change{Op: op, Path: path, Value: value, Old: old})
+}
+
+func (d *differ) value(path, l, r string) {
+	switch {
+	case l == r:
+	case l == "":
+		d.add("add", path, r, nil)
+	case r == "":
+		d.add("remove", path, nil, l)
+	default:
+		d.add("replace", path, r, l)
+	}
+}
+
+// schemas matches schemas by name below path.
+func (d *differ) schemas(path string, l, r []SchemaModel) {
+	ls := make(map[string]SchemaModel)
+	rs := make(map[string]SchemaModel)
+	names := make(map[string]bool)
+	for _, s := range l {
+		ls[s.Name] = s
+		names[s.Name] = true
+	}
+	for _, s := range r {
+		rs[s.Name] = s
+		names[s.Name] = true
+	}
+	for _, n := range sortedKeys(names) {
+		spath := path + "/schemas/" + escapePointer(n)
+		lm, inLeft := ls[n]
+		rm, inRight := rs[n]
+		switch {
+		case !inRight:
+			d.add("remove", spath, nil, lm)
+		case !inLeft:
+			d.add("add", spath, rm, nil)
+		default:
+			d.schema(spath, lm, rm)
+		}
+	}
+}
+
+func (d *differ) schema(path string, l, r SchemaModel) {
+	d.value(path+"/owner", l.Owner, r.Owner)
+	d.value(path+"/comment", l.Comment, r.Comment)
+	d.properties(path+"/properties", l.Properties, r.Properties)
+	d.grants(path+"/grants", l.Grants, r.Grants)
+
+	lt := make(map[string]TableModel)
+	rt := make(map[string]TableModel)
+	names := make(map[string]bool)
+	for _, t := range l.Tables {
+		lt[t.Name] = t
+		names[t.Name] = true
+	}
+	for _, t := range r.Tables {
+		rt[t.Name] = t
+		names[t.Name] = true
+	}
+	for _, n := range sortedKeys(names) {
+		tpath := path + "/tables/" + escapePointer(n)
+		lm, inLeft := lt[n]
+		rm, inRight := rt[n]
+		switch {
+		case !inRight:
+			d.add("remove", tpath, nil, lm)
+		case !inLeft:
+			d.add("add", tpath, rm, nil)
+		default:
+			d.table(tpath, lm, rm)
+		}
+	}
+}
+
+func (d *differ) table(path string, l, r TableModel) {
+	d.value(path+"/type", l.Type, r.Type)
+	d.value(path+"/owner", l.Owner, r.Owner)
+	d.value(path+"/comment", l.Comment, r.Comment)
+
+	lc := make(map[string]ColumnModel)
+	for _, c := range l.Columns {
+		lc[c.Name] = c
+	}
+	rc := make(map[string]ColumnModel)
+	for _, c := range r.Columns {
+		rc[c.Name] = c
+	}
+	// Columns are reported in left order, then columns only on the right.
+	for _, c := range l.Columns {
+		cpath := path + "/columns/" + escapePointer(c.Name)
+		rcol, ok := rc[c.Name]
+		if !ok {
+			d.add("remove", cpath, nil, c)
+			continue
+		}
+		d.value(cpath+"/type", c.Type, rcol.Type)
+		if c.Nullable != rcol.Nullable {
+			d.add("replace", cpath+"/nullable", rcol.Nullable, c.Nullable)
+		}
+		d.value(cpath+"/comment", c.Comment, rcol.Comment)
+	}
+	for _, c := range r.Columns {
+		if _, ok := lc[c.Name]; !ok {
+			d.add("add", path+"/columns/"+escapePointer(c.Name), c, nil)
+		}
+	}
+
+	d.properties(path+"/properties", l.Properties, r.Properties)
+	d.grants(path+"/grants", l.Grants, r.Grants)
+}
+
+func (d *differ) properties(path string, l, r map[string]string) {
+	keys := make(map[string]bool)
+	for k := range l {
+		keys[k] = true
+	}
+	for k := range r {
+		keys[k] = true
+	}
+	for _, k := range sortedKeys(keys) {
+		lv, inLeft := l[k]
+		rv, inRight := r[k]
+		kpath := path + "/" + escapePointer(k)
+		switch {
+		case !inRight:
+			d.add("remove", kpath, nil, lv)
+		case !inLeft:
+			d.add("add", kpath, rv, nil)
+		case lv != rv:
+			d.add("replace", kpath, rv, lv)
+		}
+	}
+}
+
+// grants matches grants by principal, case-insensitively as DiffPermissions
+// does, and reports them with the left-hand spelling.
+func (d *differ) grants(path string, l, r []PrincipalGrants) {
+	lg := make(map[string][]string)
+	rg := make(map[string][]string)
+	principals := make(map[string]string)
+	for _, g := range r {
+		key := strings.ToLower(g.Principal)
+		rg[key] = g.Privileges
+		principals[key] = g.Principal
+	}
+	for _, g := range l {
+		key := strings.ToLower(g.Principal)
+		lg[key] = g.Privileges
+		principals[key] = g.Principal
+	}
+	keys := make([]string, 0, len(principals))
+	for key := range principals {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lp, inLeft := lg[key]
+		rp, inRight := rg[key]
+		ppath := path + "/" + escapePointer(principals[key])
+		switch {
+		case !inRight:
+			d.add("remove", ppath, nil, lp)
+		case !inLeft:
+			d.add("add", ppath, rp, nil)
+		case strings.Join(lp, ",") != strings.Join(rp, ","):
+			d.add("replace", ppath, rp, lp)
+		}
+	}
+}
+
+// escapePointer escapes a JSON Pointer reference token.
+func escapePointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+// RenderModel renders a model as indented text lines for a unified diff.
+// Schema names are only included for catalogs so that two schemas with
+// different names can be compared line by line.
+func RenderModel(m *ScopeModel) []string {
+	if m.IsCatalog() {
+		return renderSchemas("", m.Schemas)
+	}
+	var lines []string
+	for _, s := range m.Schemas {
+		lines = append(lines, renderSchema("", s)...)
+	}
+	return lines
+}
+
+func renderSchemas(indent string, schemas []SchemaModel) []string {
+	var lines []string
+	for _, s := range schemas {
+		lines = append(lines, indent+"schema "+s.Name)
+		lines = append(lines, renderSchema(indent+"  ", s)...)
+	}
+	return lines
+}
+
+func renderSchema(indent string, s SchemaModel) []string {
+	lines := renderAttributes(indent, s.Owner, s.Comment, s.Properties, s.Grants)
+	for _, t := range s.Tables {
+		lines = append(lines, fmt.Sprintf("%stable %s (%s)", indent, t.Name, t.Type))
+		for _, c := range t.Columns {
+			line := fmt.Sprintf("%s  column %s %s", indent, c.Name, c.Type)
+			if !c.Nullable {
+				line += " NOT NULL"
+			}
+			if c.Comment != "" {
+				line += " -- " + c.Comment
+			}
+			lines = append(lines, line)
+		}
+		lines = append(lines, renderAttributes(indent+"  ", t.Owner, t.Comment, t.Properties, t.Grants)...)
+	}
+	return lines
+}
+
+func renderAttributes(indent, owner, comment string, properties map[string]string, grants []PrincipalGrants) []string {
+	var lines []string
+	if owner != "" {
+		lines = append(lines, indent+"owner "+owner)
+	}
+	if comment != "" {
+		lines = append(lines, fmt.Sprintf("%scomment %q", indent, comment))
+	}
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("%sproperty %s = %s", indent, k, properties[k]))
+	}
+	for _, g := range grants {
+		lines = append(lines, fmt.Sprintf("%sgrant %s: %s", indent, g.Principal, strings.Join(g.Privileges, ", ")))
+	}
+	return lines
+}
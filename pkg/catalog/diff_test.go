@@ -0,0 +1,77 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+func TestDiffModels(t *testing.T) {
+	left := &ScopeModel{Scope: "dev.sales", Schemas: []SchemaModel{{
+		Name: "sales",
+		Grants: []PrincipalGrants{
+			{Principal: "analysts", Privileges: []string{"USE_SCHEMA"}},
+			{Principal: "Jane@Example.com", Privileges: []string{"SELECT"}},
+		},
+		Tables: []TableModel{
+			{Name: "orders", Type: "MANAGED", Columns: []ColumnModel{
+				{Name: "id", Type: "bigint"},
+				{Name: "amount", Type: "int", Nullable: true},
+				{Name: "legacy", Type: "string", Nullable: true},
+			}, Properties: map[string]string{"owner.team": "a"}},
+			{Name: "tmp", Type: "MANAGED"},
+		},
+	}}}
+	right := &ScopeModel{Scope: "prod.sales", Schemas: []SchemaModel{{
+		Name: "sales",
+		Grants: []PrincipalGrants{
+			{Principal: "Analysts", Privileges: []string{"SELECT", "USE_SCHEMA"}},
+			{Principal: "jane@example.com", Privileges: []string{"SELECT"}},
+		},
+		Tables: []TableModel{
+			{Name: "orders", Type: "MANAGED", Comment: "Orders", Columns: []ColumnModel{
+				{Name: "id", Type: "bigint"},
+				{Name: "amount", Type: "decimal(10,2)", Nullable: true},
+				{Name: "region", Type: "string", Nullable: true},
+			}, Properties: map[string]string{"owner.team": "b"}},
+			{Name: "a/b", Type: "VIEW"},
+		},
+	}}}
+
+	changes, err := DiffModels(left, right)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.Op+" "+c.Path)
+	}
+	want := []string{
+		"replace /grants/analysts",
+		"add /tables/a~1b",
+		"add /tables/orders/comment",
+		"replace /tables/orders/columns/amount/type",
+		"remove /tables/orders/columns/legacy",
+		"add /tables/orders/columns/region",
+		"replace /tables/orders/properties/owner.team",
+		"remove /tables/tmp",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("changes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if _, err := DiffModels(left, &ScopeModel{Scope: "prod"}); err == nil {
+		t.Error("expected error comparing a schema with a catalog")
+	}
+}
+
+func TestTableModelForSkipsVolatileProperties(t *testing.T) {
+	tm := TableModelFor(catalog.TableInfo{Name: "t", Properties: map[string]string{
+		"delta.lastCommitTimestamp":  "1",
+		"delta.enableChangeDataFeed": "true",
+	}})
+	if len(tm.Properties) != 1 || tm.Properties["delta.enableChangeDataFeed"] != "true" {
+		t.Errorf("properties = %v", tm.Properties)
+	}
+}
@@ -0,0 +1,370 @@
+package catalog
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+// ERD is an entity-relationship graph built from informational primary and
+// foreign key constraints.
+type ERD struct {
+	Entities  []Entity
+	Relations []Relation
+}
+
+// Entity is one table. External entities are parents referenced from outside
+// the scope; their columns are unknown.
+type Entity struct {
+	ID       string
+	Name     string
+	Columns  []EntityColumn
+	External bool
+}
+
+// EntityColumn is a column with its key flags.
+type EntityColumn struct {
+	Name     string
+	Type     string
+	Nullable bool
+	PK       bool
+	FK       bool
+}
+
+// Relation is a foreign key from Child to Parent.
+type Relation struct {
+	Name          string
+	Child         string
+	ChildColumns  []string
+	Parent        string
+	ParentColumns []string
+	// Optional is set when any child column is nullable.
+	Optional bool
+}
+
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// BuildERD builds the graph of the given tables. Entity IDs are the table
+// names where possible, so diagrams of one schema stay readable.
+func BuildERD(tables []catalog.TableInfo) *ERD {
+	e := &ERD{}
+	byName := map[string]*Entity{}
+	var names []string
+	for _, t := range tables {
+		d := DescribeTable(t)
+		pk := map[string]bool{}
+		if d.PrimaryKey != nil {
+			for _, c := range d.PrimaryKey.ChildColumns {
+				pk[c] = true
+			}
+		}
+		fk := map[string]bool{}
+		for _, k := range d.ForeignKeys {
+			for _, c := range k.ChildColumns {
+				fk[c] = true
+			}
+		}
+
+		entity := &Entity{Name: t.FullName}
+		nullable := map[string]bool{}
+		for _, c := range t.Columns {
+			entity.Columns = append(entity.Columns, EntityColumn{Name: c.Name, Type: c.TypeText, Nullable: c.Nullable, PK: pk[c.Name], FK: fk[c.Name]})
+			nullable[c.Name] = c.Nullable
+		}
+		byName[strings.ToLower(t.FullName)] = entity
+		names = append(names, t.FullName)
+
+		for _, k := range d.ForeignKeys {
+			r := Relation{Name: k.Name, Child: t.FullName, ChildColumns: k.ChildColumns, Parent: k.ParentTable, ParentColumns: k.ParentColumns}
+			for _, c := range k.ChildColumns {
+				r.Optional = r.Optional || nullable[c]
+			}
+			e.Relations = append(e.Relations, r)
+		}
+	}
+
+	for i, r := range e.Relations {
+		key := strings.ToLower(r.Parent)
+		if p, ok := byName[key]; ok {
+			e.Relations[i].Parent = p.Name
+			continue
+		}
+		byName[key] = &Entity{Name: r.Parent, External: true}
+		names = append(names, r.Parent)
+	}
+
+	sort.Strings(names)
+	for _, n := range names {
+		e.Entities = append(e.Entities, *byName[strings.ToLower(n)])
+	}
+	sort.Slice(e.Relations, func(i, j int) bool {
+		if e.Relations[i].Child != e.Relations[j].Child {
+			return e.Relations[i].Child < e.Relations[j].Child
+		}
+		return e.Relations[i].Name < e.Relations[j].Name
+	})
+	e.assignIDs()
+	return e
+}
+
+// assignIDs gives every entity a diagram identifier: the short table name when
+// it is unique, otherwise the full name, with a numeric suffix on collisions.
+func (e *ERD) assignIDs() {
+	short := map[string]int{}
+	for _, ent := range e.Entities {
+		short[shortName(ent.Name)]++
+	}
+	used := map[string]bool{}
+	for i := range e.Entities {
+		name := e.Entities[i].Name
+		if short[shortName(name)] == 1 && !e.Entities[i].External {
+			name = shortName(name)
+		}
+		base := strings.Trim(nonIdentifier.ReplaceAllString(name, "_"), "_")
+		if base == "" {
+			base = "table"
+		}
+		id := base
+		for n := 2; used[id]; n++ {
+			id = fmt.Sprintf("%s_%d", base, n)
+		}
+		used[id] = true
+		e.Entities[i].ID = id
+	}
+}
+
+func shortName(fullName string) string {
+	return fullName[strings.LastIndex(fullName, ".")+1:]
+}
+
+// Filter keeps the named tables and every table within depth relations of
+// them. Unknown names are returned as an error.
+func (e *ERD) Filter(tables []string, depth int) (*ERD, error) {
+	keep := map[string]bool{}
+	for _, t := range tables {
+		ent := e.find(t)
+		if ent == nil {
+			return nil, fmt.Errorf("table %s is not in the diagram", t)
+		}
+		keep[ent.Name] = true
+	}
+
+	for i := 0; i < depth; i++ {
+		next := map[string]bool{}
+		for _, r := range e.Relations {
+			if keep[r.Child] || keep[r.Parent] {
+				next[r.Child], next[r.Parent] = true, true
+			}
+		}
+		for n := range next {
+			keep[n] = true
+		}
+	}
+
+	out := &ERD{}
+	for _, ent := range e.Entities {
+		if keep[ent.Name] {
+			out.Entities = append(out.Entities, ent)
+		}
+	}
+	for _, r := range e.Relations {
+		if keep[r.Child] && keep[r.Parent] {
+			out.Relations = append(out.Relations, r)
+		}
+	}
+	return out, nil
+}
+
+// find matches a full name, or a table name when it is unambiguous.
+func (e *ERD) find(name string) *Entity {
+	var match *Entity
+	for i := range e.Entities {
+		ent := &e.Entities[i]
+		if strings.EqualFold(ent.Name, name) {
+			return ent
+		}
+		if strings.EqualFold(shortName(ent.Name), name) {
+			if match != nil {
+				return nil
+			}
+			match = ent
+		}
+	}
+	return match
+}
+
+// KeysOnly drops the columns that are not part of a key.
+func (e *ERD) KeysOnly() *ERD {
+	out := &ERD{Relations: e.Relations}
+	for _, ent := range e.Entities {
+		var cols []EntityColumn
+		for _, c := range ent.Columns {
+			if c.PK || c.FK {
+				cols = append(cols, c)
+			}
+		}
+		ent.Columns = cols
+		out.Entities = append(out.Entities, ent)
+	}
+	return out
+}
+
+func (e *ERD) idOf(name string) string {
+	for _, ent := range e.Entities {
+		if ent.Name == name {
+			return ent.ID
+		}
+	}
+	return name
+}
+
+// parentCardinality is the crow's foot marker on the parent side.
+func (r Relation) parentCardinality() string {
+	if r.Optional {
+		return "|o"
+	}
+	return "||"
+}
+
+func (r Relation) label() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return strings.Join(r.ChildColumns, ", ")
+}
+
+// RenderMermaid renders the graph as a Mermaid erDiagram.
+func (e *ERD) RenderMermaid() string {
+	var b strings.Builder
+	b.WriteString("erDiagram\n")
+	for _, ent := range e.Entities {
+		if len(ent.Columns) == 0 {
+			fmt.Fprintf(&b, "    %s\n", ent.ID)
+			continue
+		}
+		fmt.Fprintf(&b, "    %s {\n", ent.ID)
+		for _, c := range ent.Columns {
+			var keys []string
+			if c.PK {
+				keys = append(keys, "PK")
+			}
+			if c.FK {
+				keys = append(keys, "FK")
+			}
+			line := mermaidWord(c.Type) + " " + mermaidWord(c.Name)
+			if len(keys) > 0 {
+				line += " " + strings.Join(keys, ", ")
+			}
+			fmt.Fprintf(&b, "        %s\n", line)
+		}
+		b.WriteString("    }\n")
+	}
+	for _, r := range e.Relations {
+		fmt.Fprintf(&b, "    %s %s--o{ %s : %q\n", e.idOf(r.Parent), r.parentCardinality(), e.idOf(r.Child), r.label())
+	}
+	return b.String()
+}
+
+// mermaidWord turns a name or type such as decimal(10,2) into a single word.
+func mermaidWord(s string) string {
+	w := strings.Trim(nonIdentifier.ReplaceAllString(s, "_"), "_")
+	if w == "" {
+		return "unknown"
+	}
+	return w
+}
+
+// RenderPlantUML renders the graph as a PlantUML entity diagram with key
+// columns above the separator.
+func (e *ERD) RenderPlantUML() string {
+	var b strings.Builder
+	b.WriteString("@startuml\nhide circle\nskinparam linetype ortho\n\n")
+	for _, ent := range e.Entities {
+		fmt.Fprintf(&b, "entity %q as %s", ent.Name, ent.ID)
+		if ent.External {
+			b.WriteString(" #eeeeee")
+		}
+		b.WriteString(" {\n")
+		var keys, rest []EntityColumn
+		for _, c := range ent.Columns {
+			if c.PK {
+				keys = append(keys, c)
+			} else {
+				rest = append(rest, c)
+			}
+		}
+		for _, c := range keys {
+			fmt.Fprintf(&b, "  * %s : %s <<PK>>%s\n", c.Name, c.Type, fkStereotype(c))
+		}
+		if len(keys) > 0 && len(rest) > 0 {
+			b.WriteString("  --\n")
+		}
+		for _, c := range rest {
+			mark := "  "
+			if !c.Nullable {
+				mark = "  * "
+			}
+			fmt.Fprintf(&b, "%s%s : %s%s\n", mark, c.Name, c.Type, fkStereotype(c))
+		}
+		b.WriteString("}\n\n")
+	}
+	for _, r := range e.Relations {
+		fmt.Fprintf(&b, "%s %s--o{ %s : %s\n", e.idOf(r.Parent), r.parentCardinality(), e.idOf(r.Child), r.label())
+	}
+	b.WriteString("@enduml\n")
+	return b.String()
+}
+
+func fkStereotype(c EntityColumn) string {
+	if c.FK {
+		return " <<FK>>"
+	}
+	return ""
+}
+
+// RenderDOT renders the graph as a Graphviz digraph with one HTML-like table
+// per entity and an edge from each child to its parent.
+func (e *ERD) RenderDOT() string {
+	var b strings.Builder
+	b.WriteString("digraph erd {\n  rankdir=LR;\n  node [shape=plaintext, fontname=\"Helvetica\"];\n  edge [fontname=\"Helvetica\", fontsize=10];\n\n")
+	for _, ent := range e.Entities {
+		header := "#1b3139"
+		if ent.External {
+			header = "#7b8794"
+		}
+		fmt.Fprintf(&b, "  %s [label=<<table border=\"0\" cellborder=\"1\" cellspacing=\"0\">\n", dotID(ent.ID))
+		fmt.Fprintf(&b, "    <tr><td colspan=\"2\" bgcolor=%q><font color=\"white\"><b>%s</b></font></td></tr>\n", header, html.EscapeString(ent.Name))
+		for _, c := range ent.Columns {
+			name := html.EscapeString(c.Name)
+			if c.PK {
+				name = "<u>" + name + "</u>"
+			}
+			if c.FK {
+				name += " (FK)"
+			}
+			fmt.Fprintf(&b, "    <tr><td align=\"left\">%s</td><td align=\"left\">%s</td></tr>\n", name, html.EscapeString(c.Type))
+		}
+		b.WriteString("  </table>>];\n")
+	}
+	if len(e.Relations) > 0 {
+		b.WriteString("\n")
+	}
+	for _, r := range e.Relations {
+		label := fmt.Sprintf("%s\n(%s) → (%s)", r.label(), strings.Join(r.ChildColumns, ", "), strings.Join(r.ParentColumns, ", "))
+		style := ""
+		if r.Optional {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %s -> %s [label=%s%s];\n", dotID(e.idOf(r.Child)), dotID(e.idOf(r.Parent)), dotID(label), style)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func dotID(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
@@ -0,0 +1,144 @@
+package catalog
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+func erdTables() []catalog.TableInfo {
+	pk := func(cols ...string) catalog.TableConstraint {
+		return catalog.TableConstraint{PrimaryKeyConstraint: &catalog.PrimaryKeyConstraint{Name: "pk", ChildColumns: cols}}
+	}
+	fk := func(name, col, parent string) catalog.TableConstraint {
+		return catalog.TableConstraint{ForeignKeyConstraint: &catalog.ForeignKeyConstraint{Name: name, ChildColumns: []string{col}, ParentTable: parent, ParentColumns: []string{"id"}}}
+	}
+	return []catalog.TableInfo{
+		{FullName: "main.sales.orders", Columns: []catalog.ColumnInfo{
+			{Name: "id", TypeText: "bigint"},
+			{Name: "customer_id", TypeText: "bigint"},
+			{Name: "region_code", TypeText: "string", Nullable: true},
+			{Name: "amount", TypeText: "decimal(10,2)", Nullable: true},
+		}, TableConstraints: []catalog.TableConstraint{
+			pk("id"),
+			fk("orders_customer_fk", "customer_id", "main.sales.customers"),
+			fk("orders_region_fk", "region_code", "ref.geo.regions"),
+		}},
+		{FullName: "main.sales.customers", Columns: []catalog.ColumnInfo{{Name: "id", TypeText: "bigint"}}, TableConstraints: []catalog.TableConstraint{pk("id")}},
+		{FullName: "main.sales.order_items", Columns: []catalog.ColumnInfo{{Name: "order_id", TypeText: "bigint"}}, TableConstraints: []catalog.TableConstraint{fk("", "order_id", "main.sales.orders")}},
+		{FullName: "main.sales.audit", Columns: []catalog.ColumnInfo{{Name: "event", TypeText: "string"}}},
+	}
+}
+
+func TestBuildERD(t *testing.T) {
+	e := BuildERD(erdTables())
+
+	var ids []string
+	for _, ent := range e.Entities {
+		ids = append(ids, ent.ID)
+	}
+	if want := []string{"audit", "customers", "order_items", "orders", "ref_geo_regions"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	if !e.Entities[4].External {
+		t.Error("ref.geo.regions should be external")
+	}
+	if len(e.Relations) != 3 {
+		t.Fatalf("relations = %+v", e.Relations)
+	}
+	if r := e.Relations[2]; r.Name != "orders_region_fk" || !r.Optional {
+		t.Errorf("region relation = %+v, want optional", r)
+	}
+
+	want := `erDiagram
+    audit {
+        string event
+    }
+    customers {
+        bigint id PK
+    }
+    order_items {
+        bigint order_id FK
+    }
+    orders {
+        bigint id PK
+        bigint customer_id FK
+        string region_code FK
+        decimal_10_2 amount
+    }
+    ref_geo_regions
+    orders ||--o{ order_items : "order_id"
+    customers ||--o{ orders : "orders_customer_fk"
+    ref_geo_regions |o--o{ orders : "orders_region_fk"
+`
+	if got := e.RenderMermaid(); got != want {
+		t.Errorf("RenderMermaid() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestERDFilter(t *testing.T) {
+	e := BuildERD(erdTables())
+	names := func(e *ERD) []string {
+		var out []string
+		for _, ent := range e.Entities {
+			out = append(out, ent.ID)
+		}
+		return out
+	}
+
+	got, err := e.Filter([]string{"customers"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"customers", "orders"}; !reflect.DeepEqual(names(got), want) {
+		t.Errorf("depth 1 = %v, want %v", names(got), want)
+	}
+	if len(got.Relations) != 1 {
+		t.Errorf("depth 1 relations = %+v", got.Relations)
+	}
+
+	got, _ = e.Filter([]string{"main.sales.customers"}, 2)
+	if want := []string{"customers", "order_items", "orders", "ref_geo_regions"}; !reflect.DeepEqual(names(got), want) {
+		t.Errorf("depth 2 = %v, want %v", names(got), want)
+	}
+
+	if _, err := e.Filter([]string{"missing"}, 1); err == nil {
+		t.Error("expected an error for an unknown table")
+	}
+}
+
+func TestERDKeysOnlyAndRenderers(t *testing.T) {
+	e := BuildERD(erdTables()).KeysOnly()
+	for _, ent := range e.Entities {
+		for _, c := range ent.Columns {
+			if c.Name == "amount" || c.Name == "event" {
+				t.Errorf("KeysOnly kept %s.%s", ent.Name, c.Name)
+			}
+		}
+	}
+
+	uml := e.RenderPlantUML()
+	for _, want := range []string{
+		`entity "main.sales.orders" as orders {`,
+		"  * id : bigint <<PK>>\n  --\n  * customer_id : bigint <<FK>>\n  region_code : string <<FK>>\n",
+		`entity "ref.geo.regions" as ref_geo_regions #eeeeee {`,
+		"ref_geo_regions |o--o{ orders : orders_region_fk\n",
+	} {
+		if !strings.Contains(uml, want) {
+			t.Errorf("PlantUML missing %q:\n%s", want, uml)
+		}
+	}
+
+	dot := e.RenderDOT()
+	for _, want := range []string{
+		`<td align="left"><u>id</u></td>`,
+		`"orders" -> "customers" [label="orders_customer_fk\n(customer_id) → (id)"];`,
+		`"orders" -> "ref_geo_regions" [label="orders_region_fk\n(region_code) → (id)", style=dashed];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT missing %q:\n%s", want, dot)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package catalog
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/databricks/databricks-sdk-go"
 	"github.com/databricks/databricks-sdk-go/service/catalog"
@@ -31,3 +33,52 @@ func ListFunctions(ctx context.Context, w *databricks.WorkspaceClient, catalogNa
 func GetFunction(ctx context.Context, w *databricks.WorkspaceClient, name string) (*catalog.FunctionInfo, error) {
 	return w.Functions.Get(ctx, catalog.GetFunctionRequest{Name: name})
 }
+
+// IsTableFunction reports whether fn returns a table rather than a scalar.
+func IsTableFunction(fn catalog.FunctionInfo) bool {
+	return fn.DataType == catalog.ColumnTypeNameTableType
+}
+
+// FunctionLanguage returns the routine language, e.g. "SQL" or "PYTHON".
+func FunctionLanguage(fn catalog.FunctionInfo) string {
+	if fn.RoutineBody == catalog.FunctionInfoRoutineBodySql {
+		return "SQL"
+	}
+	if fn.ExternalLanguage != "" {
+		return strings.ToUpper(fn.ExternalLanguage)
+	}
+	return string(fn.RoutineBody)
+}
+
+// FunctionParams returns the parameters of a parameter list in declaration order.
+func FunctionParams(infos *catalog.FunctionParameterInfos) []catalog.FunctionParameterInfo {
+	if infos == nil {
+		return nil
+	}
+	params := append([]catalog.FunctionParameterInfo(nil), infos.Parameters...)
+	sort.SliceStable(params, func(i, j int) bool { return params[i].Position < params[j].Position })
+	return params
+}
+
+// FunctionSignature renders a function's declaration, e.g.
+// "main.ml.score(x DOUBLE, scale INT DEFAULT 1) RETURNS DOUBLE".
+func FunctionSignature(fn catalog.FunctionInfo) string {
+	var in []string
+	for _, p := range FunctionParams(fn.InputParams) {
+		s := fmt.Sprintf("%s %s", p.Name, strings.ToUpper(p.TypeText))
+		if p.ParameterDefault != "" {
+			s += " DEFAULT " + p.ParameterDefault
+		}
+		in = append(in, s)
+	}
+
+	returns := strings.ToUpper(fn.FullDataType)
+	if IsTableFunction(fn) {
+		var out []string
+		for _, p := range FunctionParams(fn.ReturnParams) {
+			out = append(out, fmt.Sprintf("%s %s", p.Name, strings.ToUpper(p.TypeText)))
+		}
+		returns = fmt.Sprintf("TABLE (%s)", strings.Join(out, ", "))
+	}
+	return fmt.Sprintf("%s(%s) RETURNS %s", fn.FullName, strings.Join(in, ", "), returns)
+}
@@ -2,6 +2,8 @@ package catalog
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/databricks/databricks-sdk-go"
 	"github.com/databricks/databricks-sdk-go/service/catalog"
@@ -22,3 +24,120 @@ func GetPermissions(ctx context.Context, w *databricks.WorkspaceClient, securabl
 		FullName:      fullName,
 	})
 }
+
+// UpdatePermissions applies a set of grant/revoke changes to a securable.
+func UpdatePermissions(ctx context.Context, w *databricks.WorkspaceClient, securableType, fullName string, changes []catalog.PermissionsChange) (*catalog.UpdatePermissionsResponse, error) {
+	return w.Grants.Update(ctx, catalog.UpdatePermissions{
+		SecurableType: securableType,
+		FullName:      fullName,
+		Changes:       changes,
+	})
+}
+
+// ParsePrivilege normalises user input such as "all privileges" or "use-schema"
+// into the SDK privilege constant format (ALL_PRIVILEGES, USE_SCHEMA).
+func ParsePrivilege(s string) catalog.Privilege {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.NewReplacer(" ", "_", "-", "_").Replace(s)
+	return catalog.Privilege(s)
+}
+
+// BuildPermissionChanges creates one PermissionsChange per principal carrying
+// the same set of privileges to add or remove.
+func BuildPermissionChanges(principals []string, privileges []catalog.Privilege, revoke bool) []catalog.PermissionsChange {
+	var changes []catalog.PermissionsChange
+	for _, principal := range principals {
+		principal = strings.TrimSpace(principal)
+		if principal == "" {
+			continue
+		}
+		change := catalog.PermissionsChange{Principal: principal}
+		if revoke {
+			change.Remove = privileges
+		} else {
+			change.Add = privileges
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// KnownPrivilege reports whether p is a privilege Unity Catalog defines.
+func KnownPrivilege(p catalog.Privilege) bool {
+	for _, v := range p.Values() {
+		if v == p {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionChangeKind describes what an entry in a permissions diff does.
+type PermissionChangeKind string
+
+const (
+	PermissionAdd       PermissionChangeKind = "+"
+	PermissionRemove    PermissionChangeKind = "-"
+	PermissionUnchanged PermissionChangeKind = "="
+)
+
+// PermissionDiff is a single principal/privilege line of a permissions diff.
+type PermissionDiff struct {
+	Kind      PermissionChangeKind
+	Principal string
+	Privilege catalog.Privilege
+}
+
+// DiffPermissions previews the effect of applying changes to the current direct
+// grants. Adds of privileges already held and removes of privileges not held are
+// reported as unchanged, so callers can show exactly what the update will do.
+// Principals are matched case-insensitively, as Unity Catalog does.
+func DiffPermissions(current []catalog.PrivilegeAssignment, changes []catalog.PermissionsChange) []PermissionDiff {
+	held := make(map[string]map[catalog.Privilege]bool)
+	for _, pa := range current {
+		key := strings.ToLower(pa.Principal)
+		if held[key] == nil {
+			held[key] = make(map[catalog.Privilege]bool)
+		}
+		for _, p := range pa.Privileges {
+			held[key][p] = true
+		}
+	}
+
+	var diffs []PermissionDiff
+	for _, ch := range changes {
+		key := strings.ToLower(ch.Principal)
+		for _, p := range ch.Add {
+			kind := PermissionAdd
+			if held[key][p] {
+				kind = PermissionUnchanged
+			}
+			diffs = append(diffs, PermissionDiff{Kind: kind, Principal: ch.Principal, Privilege: p})
+		}
+		for _, p := range ch.Remove {
+			kind := PermissionRemove
+			if !held[key][p] {
+				kind = PermissionUnchanged
+			}
+			diffs = append(diffs, PermissionDiff{Kind: kind, Principal: ch.Principal, Privilege: p})
+		}
+	}
+
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].Principal != diffs[j].Principal {
+			return diffs[i].Principal < diffs[j].Principal
+		}
+		return diffs[i].Privilege < diffs[j].Privilege
+	})
+	return diffs
+}
+
+// HasPermissionChanges reports whether a diff contains anything to apply.
+func HasPermissionChanges(diffs []PermissionDiff) bool {
+	for _, d := range diffs {
+		if d.Kind != PermissionUnchanged {
+			return true
+		}
+	}
+	return false
+}
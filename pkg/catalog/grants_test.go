@@ -0,0 +1,88 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+func TestBuildPermissionChanges(t *testing.T) {
+	privileges := []catalog.Privilege{catalog.PrivilegeSelect}
+	got := BuildPermissionChanges([]string{" analysts ", "", "bob@example.com"}, privileges, false)
+	want := []catalog.PermissionsChange{
+		{Principal: "analysts", Add: privileges},
+		{Principal: "bob@example.com", Add: privileges},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("grant = %+v, want %+v", got, want)
+	}
+
+	got = BuildPermissionChanges([]string{"analysts"}, privileges, true)
+	want = []catalog.PermissionsChange{{Principal: "analysts", Remove: privileges}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("revoke = %+v, want %+v", got, want)
+	}
+}
+
+func TestDiffPermissions(t *testing.T) {
+	current := []catalog.PrivilegeAssignment{
+		{Principal: "Analysts", Privileges: []catalog.Privilege{catalog.PrivilegeSelect, catalog.PrivilegeUseSchema}},
+		{Principal: "bob@example.com", Privileges: []catalog.Privilege{catalog.PrivilegeModify}},
+	}
+	tests := []struct {
+		name    string
+		changes []catalog.PermissionsChange
+		want    []PermissionDiff
+		changed bool
+	}{
+		{
+			name:    "add",
+			changes: []catalog.PermissionsChange{{Principal: "engineers", Add: []catalog.Privilege{catalog.PrivilegeSelect}}},
+			want:    []PermissionDiff{{PermissionAdd, "engineers", catalog.PrivilegeSelect}},
+			changed: true,
+		},
+		{
+			name:    "revoke",
+			changes: []catalog.PermissionsChange{{Principal: "bob@example.com", Remove: []catalog.Privilege{catalog.PrivilegeModify}}},
+			want:    []PermissionDiff{{PermissionRemove, "bob@example.com", catalog.PrivilegeModify}},
+			changed: true,
+		},
+		{
+			name: "unchanged",
+			changes: []catalog.PermissionsChange{
+				{Principal: "Analysts", Add: []catalog.Privilege{catalog.PrivilegeSelect}},
+				{Principal: "bob@example.com", Remove: []catalog.Privilege{catalog.PrivilegeSelect}},
+			},
+			want: []PermissionDiff{
+				{PermissionUnchanged, "Analysts", catalog.PrivilegeSelect},
+				{PermissionUnchanged, "bob@example.com", catalog.PrivilegeSelect},
+			},
+		},
+		{
+			name: "mixed case principal",
+			changes: []catalog.PermissionsChange{{
+				Principal: "analysts",
+				Add:       []catalog.Privilege{catalog.PrivilegeSelect, catalog.PrivilegeModify},
+				Remove:    []catalog.Privilege{catalog.PrivilegeUseSchema},
+			}},
+			want: []PermissionDiff{
+				{PermissionAdd, "analysts", catalog.PrivilegeModify},
+				{PermissionUnchanged, "analysts", catalog.PrivilegeSelect},
+				{PermissionRemove, "analysts", catalog.PrivilegeUseSchema},
+			},
+			changed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiffPermissions(current, tt.changes)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffPermissions = %+v, want %+v", got, tt.want)
+			}
+			if HasPermissionChanges(got) != tt.changed {
+				t.Errorf("HasPermissionChanges = %v, want %v", !tt.changed, tt.changed)
+			}
+		})
+	}
+}
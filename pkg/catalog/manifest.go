@@ -0,0 +1,216 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestVersion is the current grants manifest format version.
+const ManifestVersion = 1
+
+// GrantManifest is the declarative, reviewable form of the direct grants on a
+// set of securables. Securables listed in a manifest are authoritative: any
+// live grant not present in the manifest is planned for removal.
+type GrantManifest struct {
+	Version    int               `yaml:"version"`
+	Securables []SecurableGrants `yaml:"securables"`
+}
+
+// SecurableGrants holds the desired direct grants for one securable.
+type SecurableGrants struct {
+	Securable `yaml:",inline"`
+	Grants    []PrincipalGrants `yaml:"grants"`
+}
+
+// PrincipalGrants lists the privileges held by one principal.
+type PrincipalGrants struct {
+	Principal  string   `yaml:"principal"`
+	Privileges []string `yaml:"privileges,flow"`
+}
+
+// LoadManifest reads and validates a grants manifest from disk.
+func LoadManifest(path string) (*GrantManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var m GrantManifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if m.Version != ManifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d (expected %d)", m.Version, ManifestVersion)
+	}
+	for i, s := range m.Securables {
+		if s.Type == "" || s.FullName == "" {
+			return nil, fmt.Errorf("securable #%d is missing type or name", i+1)
+		}
+	}
+	return &m, nil
+}
+
+// SaveManifest writes a grants manifest to disk.
+func SaveManifest(path string, m *GrantManifest) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(m); err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// ExportManifest collects the direct grants for every securable in scope.
+// Principals and privileges are sorted so that exports produce stable diffs.
+func ExportManifest(ctx context.Context, w *databricks.WorkspaceClient, scope string) (*GrantManifest, error) {
+	m := &GrantManifest{Version: ManifestVersion}
+	err := WalkSecurables(ctx, w, scope, func(s Securable) error {
+		perms, err := GetPermissions(ctx, w, s.Type, s.FullName)
+		if err != nil {
+			return fmt.Errorf("failed to get permissions for %s: %w", s.FullName, err)
+		}
+		m.Securables = append(m.Securables, SecurableGrants{
+			Securable: Securable{Type: s.Type, FullName: s.FullName},
+			Grants:    toPrincipalGrants(perms.PrivilegeAssignments),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SecurablePlan is the planned set of changes for a single securable.
+type SecurablePlan struct {
+	Securable Securable
+	Live      []catalog.PrivilegeAssignment
+	Changes   []catalog.PermissionsChange
+}
+
+// PlanManifest compares every securable in the manifest against its live direct
+// grants and returns only the securables that need changes.
+func PlanManifest(ctx context.Context, w *databricks.WorkspaceClient, m *GrantManifest) ([]SecurablePlan, error) {
+	var plans []SecurablePlan
+	for _, s := range m.Securables {
+		perms, err := GetPermissions(ctx, w, s.Type, s.FullName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get permissions for %s: %w", s.FullName, err)
+		}
+		changes, err := PlanManifestChanges(s.Grants, perms.PrivilegeAssignments)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.FullName, err)
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		plans = append(plans, SecurablePlan{Securable: s.Securable, Live: perms.PrivilegeAssignments, Changes: changes})
+	}
+	return plans, nil
+}
+
+// PlanManifestChanges computes the changes needed to turn the live direct grants
+// into the desired ones. It returns nil when the two already match. Principals
+// are matched case-insensitively and keep their live spelling; privileges are
+// normalised with ParsePrivilege and must be known.
+func PlanManifestChanges(desired []PrincipalGrants, live []catalog.PrivilegeAssignment) ([]catalog.PermissionsChange, error) {
+	names := make(map[string]string)
+	want := make(map[string]map[catalog.Privilege]bool)
+	for _, g := range desired {
+		key := strings.ToLower(g.Principal)
+		names[key] = g.Principal
+		if want[key] == nil {
+			want[key] = make(map[catalog.Privilege]bool)
+		}
+		for _, p := range g.Privileges {
+			priv := ParsePrivilege(p)
+			if !KnownPrivilege(priv) {
+				return nil, fmt.Errorf("unknown privilege %q for %s", p, g.Principal)
+			}
+			want[key][priv] = true
+		}
+	}
+	have := make(map[string]map[catalog.Privilege]bool)
+	for _, pa := range live {
+		key := strings.ToLower(pa.Principal)
+		names[key] = pa.Principal
+		if have[key] == nil {
+			have[key] = make(map[catalog.Privilege]bool)
+		}
+		for _, p := range pa.Privileges {
+			have[key][p] = true
+		}
+	}
+
+	principals := make(map[string]bool)
+	for p := range names {
+		principals[p] = true
+	}
+
+	var changes []catalog.PermissionsChange
+	for _, key := range sortedKeys(principals) {
+		change := catalog.PermissionsChange{Principal: names[key]}
+		for p := range want[key] {
+			if !have[key][p] {
+				change.Add = append(change.Add, p)
+			}
+		}
+		for p := range have[key] {
+			if !want[key][p] {
+				change.Remove = append(change.Remove, p)
+			}
+		}
+		if len(change.Add) == 0 && len(change.Remove) == 0 {
+			continue
+		}
+		sortPrivileges(change.Add)
+		sortPrivileges(change.Remove)
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func toPrincipalGrants(assignments []catalog.PrivilegeAssignment) []PrincipalGrants {
+	var grants []PrincipalGrants
+	for _, pa := range assignments {
+		if pa.Principal == "" || len(pa.Privileges) == 0 {
+			continue
+		}
+		privs := make([]catalog.Privilege, len(pa.Privileges))
+		copy(privs, pa.Privileges)
+		sortPrivileges(privs)
+
+		g := PrincipalGrants{Principal: pa.Principal}
+		for _, p := range privs {
+			g.Privileges = append(g.Privileges, string(p))
+		}
+		grants = append(grants, g)
+	}
+	sort.Slice(grants, func(i, j int) bool { return grants[i].Principal < grants[j].Principal })
+	return grants
+}
+
+func sortPrivileges(privs []catalog.Privilege) {
+	sort.Slice(privs, func(i, j int) bool { return privs[i] < privs[j] })
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,88 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+func TestPlanManifestChanges(t *testing.T) {
+	live := []catalog.PrivilegeAssignment{
+		{Principal: "Analysts", Privileges: []catalog.Privilege{catalog.PrivilegeSelect, catalog.PrivilegeUseSchema}},
+		{Principal: "bob@example.com", Privileges: []catalog.Privilege{catalog.PrivilegeModify}},
+	}
+	tests := []struct {
+		name    string
+		desired []PrincipalGrants
+		want    []catalog.PermissionsChange
+	}{
+		{
+			name: "no-op",
+			desired: []PrincipalGrants{
+				{Principal: "Analysts", Privileges: []string{"SELECT", "USE_SCHEMA"}},
+				{Principal: "bob@example.com", Privileges: []string{"MODIFY"}},
+			},
+		},
+		{
+			name: "case normalization",
+			desired: []PrincipalGrants{
+				{Principal: "analysts", Privileges: []string{"select", "use schema"}},
+				{Principal: "Bob@Example.com", Privileges: []string{"modify"}},
+			},
+		},
+		{
+			name: "add",
+			desired: []PrincipalGrants{
+				{Principal: "Analysts", Privileges: []string{"SELECT", "USE_SCHEMA", "READ_VOLUME"}},
+				{Principal: "bob@example.com", Privileges: []string{"MODIFY"}},
+				{Principal: "engineers", Privileges: []string{"all-privileges"}},
+			},
+			want: []catalog.PermissionsChange{
+				{Principal: "Analysts", Add: []catalog.Privilege{catalog.PrivilegeReadVolume}},
+				{Principal: "engineers", Add: []catalog.Privilege{catalog.PrivilegeAllPrivileges}},
+			},
+		},
+		{
+			name: "remove",
+			desired: []PrincipalGrants{
+				{Principal: "analysts", Privileges: []string{"SELECT"}},
+			},
+			want: []catalog.PermissionsChange{
+				{Principal: "Analysts", Remove: []catalog.Privilege{catalog.PrivilegeUseSchema}},
+				{Principal: "bob@example.com", Remove: []catalog.Privilege{catalog.PrivilegeModify}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PlanManifestChanges(tt.desired, live)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PlanManifestChanges = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := PlanManifestChanges([]PrincipalGrants{{Principal: "analysts", Privileges: []string{"READ_EVERYTHING"}}}, live); err == nil {
+		t.Error("expected an error for an unknown privilege")
+	}
+}
+
+func TestToPrincipalGrants(t *testing.T) {
+	got := toPrincipalGrants([]catalog.PrivilegeAssignment{
+		{Principal: "zed", Privileges: []catalog.Privilege{catalog.PrivilegeSelect, catalog.PrivilegeModify}},
+		{Principal: "", Privileges: []catalog.Privilege{catalog.PrivilegeSelect}},
+		{Principal: "empty"},
+		{Principal: "analysts", Privileges: []catalog.Privilege{catalog.PrivilegeUseSchema}},
+	})
+	want := []PrincipalGrants{
+		{Principal: "analysts", Privileges: []string{"USE_SCHEMA"}},
+		{Principal: "zed", Privileges: []string{"MODIFY", "SELECT"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("toPrincipalGrants = %+v, want %+v", got, want)
+	}
+}
@@ -3,6 +3,7 @@ package catalog
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/databricks/databricks-sdk-go"
 	"github.com/databricks/databricks-sdk-go/service/catalog"
@@ -27,7 +28,79 @@ func ListModels(ctx context.Context, w *databricks.WorkspaceClient, catalogName,
 	return all, nil
 }
 
-// GetModel retrieves details for a specific registered model.
+// GetModel retrieves details for a specific registered model, including its aliases.
 func GetModel(ctx context.Context, w *databricks.WorkspaceClient, fullName string) (*catalog.RegisteredModelInfo, error) {
-	return w.RegisteredModels.Get(ctx, catalog.GetRegisteredModelRequest{FullName: fullName})
+	return w.RegisteredModels.Get(ctx, catalog.GetRegisteredModelRequest{FullName: fullName, IncludeAliases: true})
+}
+
+// ListModelVersions retrieves all versions of a registered model, newest first.
+func ListModelVersions(ctx context.Context, w *databricks.WorkspaceClient, fullName string) ([]catalog.ModelVersionInfo, error) {
+	it := w.ModelVersions.List(ctx, catalog.ListModelVersionsRequest{FullName: fullName})
+	var all []catalog.ModelVersionInfo
+	for it.HasNext(ctx) {
+		v, err := it.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate model versions: %w", err)
+		}
+		all = append(all, v)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Version > all[j].Version })
+	return all, nil
+}
+
+// GetModelVersion retrieves one version of a registered model.
+func GetModelVersion(ctx context.Context, w *databricks.WorkspaceClient, fullName string, version int) (*catalog.ModelVersionInfo, error) {
+	return w.ModelVersions.Get(ctx, catalog.GetModelVersionRequest{FullName: fullName, Version: version, IncludeAliases: true})
+}
+
+// SetModelAlias points an alias (e.g. "champion") at a model version, moving
+// it if it already exists.
+func SetModelAlias(ctx context.Context, w *databricks.WorkspaceClient, fullName, alias string, version int) error {
+	_, err := w.RegisteredModels.SetAlias(ctx, catalog.SetRegisteredModelAliasRequest{FullName: fullName, Alias: alias, VersionNum: version})
+	if err != nil {
+		return fmt.Errorf("failed to set alias %s on %s: %w", alias, fullName, err)
+	}
+	return nil
+}
+
+// DeleteModelAlias removes an alias from a registered model.
+func DeleteModelAlias(ctx context.Context, w *databricks.WorkspaceClient, fullName, alias string) error {
+	if err := w.RegisteredModels.DeleteAlias(ctx, catalog.DeleteAliasRequest{FullName: fullName, Alias: alias}); err != nil {
+		return fmt.Errorf("failed to delete alias %s from %s: %w", alias, fullName, err)
+	}
+	return nil
+}
+
+// AliasesByVersion groups a model's aliases by the version they point at.
+func AliasesByVersion(aliases []catalog.RegisteredModelAlias) map[int][]string {
+	out := make(map[int][]string)
+	for _, a := range aliases {
+		out[a.VersionNum] = append(out[a.VersionNum], a.AliasName)
+	}
+	for _, names := range out {
+		sort.Strings(names)
+	}
+	return out
+}
+
+// ModelVersionDependencies lists the tables, functions, connections and
+// credentials a model version was logged with, e.g. "table main.sales.orders".
+func ModelVersionDependencies(v catalog.ModelVersionInfo) []string {
+	if v.ModelVersionDependencies == nil {
+		return nil
+	}
+	var deps []string
+	for _, d := range v.ModelVersionDependencies.Dependencies {
+		switch {
+		case d.Table != nil:
+			deps = append(deps, "table "+d.Table.TableFullName)
+		case d.Function != nil:
+			deps = append(deps, "function "+d.Function.FunctionFullName)
+		case d.Connection != nil:
+			deps = append(deps, "connection "+d.Connection.ConnectionName)
+		case d.Credential != nil:
+			deps = append(deps, "credential "+d.Credential.CredentialName)
+		}
+	}
+	return deps
 }
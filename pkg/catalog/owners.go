@@ -0,0 +1,154 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+// SetOwner changes the owner of a securable through the Update API of its kind.
+func SetOwner(ctx context.Context, w *databricks.WorkspaceClient, s Securable, owner string) error {
+	var err error
+	switch s.Kind {
+	case KindCatalog:
+		_, err = w.Catalogs.Update(ctx, catalog.UpdateCatalog{Name: s.FullName, Owner: owner})
+	case KindSchema:
+		_, err = w.Schemas.Update(ctx, catalog.UpdateSchema{FullName: s.FullName, Owner: owner})
+	case KindTable, KindView:
+		err = w.Tables.Update(ctx, catalog.UpdateTableRequest{FullName: s.FullName, Owner: owner})
+	case KindVolume:
+		_, err = w.Volumes.Update(ctx, catalog.UpdateVolumeRequestContent{Name: s.FullName, Owner: owner})
+	case KindFunction:
+		_, err = w.Functions.Update(ctx, catalog.UpdateFunction{Name: s.FullName, Owner: owner})
+	case KindModel:
+		_, err = w.RegisteredModels.Update(ctx, catalog.UpdateRegisteredModelRequest{FullName: s.FullName, Owner: owner})
+	default:
+		return fmt.Errorf("changing the owner of a %s is not supported", s.Kind)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set owner of %s: %w", s.FullName, err)
+	}
+	return nil
+}
+
+// FindOwnedSecurables returns every securable under the scopes owned by owner.
+func FindOwnedSecurables(ctx context.Context, w *databricks.WorkspaceClient, scopes []string, owner string) ([]Securable, error) {
+	var owned []Securable
+	for _, scope := range scopes {
+		err := WalkSecurables(ctx, w, scope, func(s Securable) error {
+			if strings.EqualFold(s.Owner, owner) {
+				owned = append(owned, s)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return owned, nil
+}
+
+// Journal entry states.
+const (
+	JournalPending = "pending"
+	JournalDone    = "done"
+	JournalSkipped = "skipped"
+	JournalFailed  = "failed"
+)
+
+// JournalEntry records the transfer state of one securable.
+type JournalEntry struct {
+	Securable Securable `json:"securable"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// OwnerJournal tracks an ownership transfer on disk so an interrupted run can
+// be resumed without re-scanning or re-applying finished objects.
+type OwnerJournal struct {
+	From    string         `json:"from"`
+	To      string         `json:"to"`
+	Scopes  []string       `json:"scopes"`
+	Entries []JournalEntry `json:"entries"`
+
+	path string
+}
+
+// NewOwnerJournal creates a journal with every securable pending.
+func NewOwnerJournal(path, from, to string, scopes []string, securables []Securable) *OwnerJournal {
+	j := &OwnerJournal{From: from, To: to, Scopes: scopes, path: path}
+	for _, s := range securables {
+		j.Entries = append(j.Entries, JournalEntry{Securable: s, Status: JournalPending})
+	}
+	return j
+}
+
+// LoadOwnerJournal reads a journal from disk. It returns nil without an error
+// if the file does not exist.
+func LoadOwnerJournal(path string) (*OwnerJournal, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	var j OwnerJournal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("failed to parse journal %s: %w", path, err)
+	}
+	j.path = path
+	return &j, nil
+}
+
+// Matches reports whether the journal belongs to the same transfer.
+func (j *OwnerJournal) Matches(from, to string, scopes []string) bool {
+	return strings.EqualFold(j.From, from) && strings.EqualFold(j.To, to) && strings.Join(j.Scopes, ",") == strings.Join(scopes, ",")
+}
+
+// Set updates the status of entry i and persists the journal immediately.
+func (j *OwnerJournal) Set(i int, status string, err error) error {
+	j.Entries[i].Status = status
+	j.Entries[i].Error = ""
+	if err != nil {
+		j.Entries[i].Error = err.Error()
+	}
+	return j.Save()
+}
+
+// Save writes the journal to disk.
+func (j *OwnerJournal) Save() error {
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode journal: %w", err)
+	}
+	if err := os.WriteFile(j.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return nil
+}
+
+// Remove deletes the journal file.
+func (j *OwnerJournal) Remove() error {
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove journal: %w", err)
+	}
+	return nil
+}
+
+// Count returns the number of entries with the given status.
+func (j *OwnerJournal) Count(status string) int {
+	n := 0
+	for _, e := range j.Entries {
+		if e.Status == status {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,72 @@
+package catalog
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestOwnerJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	if j, err := LoadOwnerJournal(path); err != nil || j != nil {
+		t.Fatalf("LoadOwnerJournal(missing) = %v, %v; want nil, nil", j, err)
+	}
+
+	j := NewOwnerJournal(path, "jane@example.com", "data-platform", []string{"main", "dev"}, []Securable{
+		newSecurable(KindCatalog, "main", "jane@example.com"),
+		newSecurable(KindSchema, "main.sales", "jane@example.com"),
+		newSecurable(KindTable, "main.sales.orders", "jane@example.com"),
+	})
+	if got := j.Count(JournalPending); got != 3 {
+		t.Errorf("pending = %d, want 3", got)
+	}
+	if err := j.Set(0, JournalDone, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Set(1, JournalSkipped, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Set(2, JournalFailed, errors.New("permission denied")); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadOwnerJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for status, want := range map[string]int{JournalPending: 0, JournalDone: 1, JournalSkipped: 1, JournalFailed: 1} {
+		if got := loaded.Count(status); got != want {
+			t.Errorf("Count(%s) = %d, want %d", status, got, want)
+		}
+	}
+	if e := loaded.Entries[2]; e.Error != "permission denied" || e.Securable.FullName != "main.sales.orders" {
+		t.Errorf("entry = %+v", e)
+	}
+	if err := loaded.Set(2, JournalDone, nil); err != nil || loaded.Entries[2].Error != "" {
+		t.Errorf("Set(done) kept error %q, %v", loaded.Entries[2].Error, err)
+	}
+
+	tests := []struct {
+		from, to string
+		scopes   []string
+		want     bool
+	}{
+		{"jane@example.com", "data-platform", []string{"main", "dev"}, true},
+		{"Jane@Example.com", "Data-Platform", []string{"main", "dev"}, true},
+		{"bob@example.com", "data-platform", []string{"main", "dev"}, false},
+		{"jane@example.com", "admins", []string{"main", "dev"}, false},
+		{"jane@example.com", "data-platform", []string{"main"}, false},
+	}
+	for _, tt := range tests {
+		if got := loaded.Matches(tt.from, tt.to, tt.scopes); got != tt.want {
+			t.Errorf("Matches(%s, %s, %v) = %v, want %v", tt.from, tt.to, tt.scopes, got, tt.want)
+		}
+	}
+
+	if err := loaded.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	if j, err := LoadOwnerJournal(path); err != nil || j != nil {
+		t.Errorf("journal still present after Remove: %v, %v", j, err)
+	}
+}
@@ -0,0 +1,47 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+// ListCatalogs retrieves all catalogs in the metastore.
+func ListCatalogs(ctx context.Context, w *databricks.WorkspaceClient) ([]catalog.CatalogInfo, error) {
+	it := w.Catalogs.List(ctx, catalog.ListCatalogsRequest{})
+	var all []catalog.CatalogInfo
+	for it.HasNext(ctx) {
+		c, err := it.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate catalogs: %w", err)
+		}
+		all = append(all, c)
+	}
+	return all, nil
+}
+
+// GetCatalog retrieves details for a specific catalog.
+func GetCatalog(ctx context.Context, w *databricks.WorkspaceClient, name string) (*catalog.CatalogInfo, error) {
+	return w.Catalogs.Get(ctx, catalog.GetCatalogRequest{Name: name})
+}
+
+// ListSchemas retrieves all schemas in a specific catalog.
+func ListSchemas(ctx context.Context, w *databricks.WorkspaceClient, catalogName string) ([]catalog.SchemaInfo, error) {
+	it := w.Schemas.List(ctx, catalog.ListSchemasRequest{CatalogName: catalogName})
+	var all []catalog.SchemaInfo
+	for it.HasNext(ctx) {
+		s, err := it.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate schemas: %w", err)
+		}
+		all = append(all, s)
+	}
+	return all, nil
+}
+
+// GetSchema retrieves details for a specific schema.
+func GetSchema(ctx context.Context, w *databricks.WorkspaceClient, fullName string) (*catalog.SchemaInfo, error) {
+	return w.Schemas.Get(ctx, catalog.GetSchemaRequest{FullName: fullName})
+}
@@ -0,0 +1,163 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+// volatileProperties are table property prefixes that change on every write
+// and are left out of models so they don't show up as differences.
+var volatileProperties = []string{
+	"delta.lastCommitTimestamp",
+	"delta.lastUpdateVersion",
+	"transient_lastDdlTime",
+	"spark.sql.statistics.",
+}
+
+// ScopeModel is the comparable structure of a catalog or a single schema:
+// schemas, tables, columns, comments, properties and direct grants.
+type ScopeModel struct {
+	Scope   string        `json:"scope"`
+	Schemas []SchemaModel `json:"schemas"`
+}
+
+// SchemaModel describes one schema and its tables.
+type SchemaModel struct {
+	Name       string            `json:"name"`
+	Owner      string            `json:"owner,omitempty"`
+	Comment    string            `json:"comment,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+	Grants     []PrincipalGrants `json:"grants,omitempty"`
+	Tables     []TableModel      `json:"tables"`
+}
+
+// TableModel describes one table or view.
+type TableModel struct {
+	Name       string            `json:"name"`
+	Type       string            `json:"type"`
+	Owner      string            `json:"owner,omitempty"`
+	Comment    string            `json:"comment,omitempty"`
+	Columns    []ColumnModel     `json:"columns"`
+	Properties map[string]string `json:"properties,omitempty"`
+	Grants     []PrincipalGrants `json:"grants,omitempty"`
+}
+
+// ColumnModel describes one column.
+type ColumnModel struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// IsCatalog reports whether the model covers a whole catalog.
+func (m *ScopeModel) IsCatalog() bool {
+	return !strings.Contains(m.Scope, ".")
+}
+
+// LoadScopeModel reads the model of a "catalog" or "catalog.schema" scope.
+// Direct grants are fetched for every schema and table when withGrants is set.
+// information_schema is skipped.
+func LoadScopeModel(ctx context.Context, w *databricks.WorkspaceClient, scope string, withGrants bool) (*ScopeModel, error) {
+	catalogName, schemaName, err := ParseScope(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas []catalog.SchemaInfo
+	if schemaName == "" {
+		schemas, err = ListSchemas(ctx, w, catalogName)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		s, err := GetSchema(ctx, w, scope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get schema %s: %w", scope, err)
+		}
+		schemas = []catalog.SchemaInfo{*s}
+	}
+
+	m := &ScopeModel{Scope: scope}
+	for _, s := range schemas {
+		if s.Name == "information_schema" {
+			continue
+		}
+		sm, err := loadSchemaModel(ctx, w, s, withGrants)
+		if err != nil {
+			return nil, err
+		}
+		m.Schemas = append(m.Schemas, *sm)
+	}
+	sort.Slice(m.Schemas, func(i, j int) bool { return m.Schemas[i].Name < m.Schemas[j].Name })
+	return m, nil
+}
+
+func loadSchemaModel(ctx context.Context, w *databricks.WorkspaceClient, s catalog.SchemaInfo, withGrants bool) (*SchemaModel, error) {
+	sm := &SchemaModel{Name: s.Name, Owner: s.Owner, Comment: s.Comment, Properties: s.Properties}
+	if withGrants {
+		grants, err := directGrants(ctx, w, string(catalog.SecurableTypeSchema), s.FullName)
+		if err != nil {
+			return nil, err
+		}
+		sm.Grants = grants
+	}
+
+	tables, err := ListTables(ctx, w, s.CatalogName, s.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tables {
+		tm := TableModelFor(t)
+		if withGrants {
+			grants, err := directGrants(ctx, w, string(catalog.SecurableTypeTable), t.FullName)
+			if err != nil {
+				return nil, err
+			}
+			tm.Grants = grants
+		}
+		sm.Tables = append(sm.Tables, tm)
+	}
+	sort.Slice(sm.Tables, func(i, j int) bool { return sm.Tables[i].Name < sm.Tables[j].Name })
+	return sm, nil
+}
+
+// TableModelFor converts a TableInfo into a TableModel without grants.
+func TableModelFor(t catalog.TableInfo) TableModel {
+	tm := TableModel{Name: t.Name, Type: string(t.TableType), Owner: t.Owner, Comment: t.Comment}
+	for _, c := range t.Columns {
+		tm.Columns = append(tm.Columns, ColumnModel{Name: c.Name, Type: c.TypeText, Nullable: c.Nullable, Comment: c.Comment})
+	}
+	for k, v := range t.Properties {
+		if isVolatileProperty(k) {
+			continue
+		}
+		if tm.Properties == nil {
+			tm.Properties = make(map[string]string)
+		}
+		tm.Properties[k] = v
+	}
+	return tm
+}
+
+func isVolatileProperty(key string) bool {
+	for _, p := range volatileProperties {
+		if strings.HasPrefix(key, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func directGrants(ctx context.Context, w *databricks.WorkspaceClient, securableType, fullName string) ([]PrincipalGrants, error) {
+	perms, err := GetPermissions(ctx, w, securableType, fullName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permissions for %s: %w", fullName, err)
+	}
+	return toPrincipalGrants(perms.PrivilegeAssignments), nil
+}
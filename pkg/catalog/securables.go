@@ -0,0 +1,78 @@
+package catalog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+// ObjectKind is a kind of Unity Catalog object the explorer navigates.
+type ObjectKind string
+
+const (
+	KindMetastore         ObjectKind = "metastore"
+	KindCatalog           ObjectKind = "catalog"
+	KindSchema            ObjectKind = "schema"
+	KindTable             ObjectKind = "table"
+	KindView              ObjectKind = "view"
+	KindVolume            ObjectKind = "volume"
+	KindFunction          ObjectKind = "function"
+	KindModel             ObjectKind = "model"
+	KindConnection        ObjectKind = "connection"
+	KindExternalLocation  ObjectKind = "external_location"
+	KindStorageCredential ObjectKind = "storage_credential"
+)
+
+// securableTypes maps every navigable object kind to the securable type the
+// grants API expects for it.
+var securableTypes = map[ObjectKind]catalog.SecurableType{
+	KindMetastore: catalog.SecurableTypeMetastore,
+	KindCatalog:   catalog.SecurableTypeCatalog,
+	KindSchema:    catalog.SecurableTypeSchema,
+	KindTable:     catalog.SecurableTypeTable,
+	// Views share the TABLE securable type.
+	KindView:     catalog.SecurableTypeTable,
+	KindVolume:   catalog.SecurableTypeVolume,
+	KindFunction: catalog.SecurableTypeFunction,
+	// Registered models have no securable type of their own: the grants API
+	// manages them as FUNCTION securables.
+	KindModel:             catalog.SecurableTypeFunction,
+	KindConnection:        catalog.SecurableTypeConnection,
+	KindExternalLocation:  catalog.SecurableTypeExternalLocation,
+	KindStorageCredential: catalog.SecurableTypeStorageCredential,
+}
+
+// SecurableTypeFor returns the grants API securable type for an object kind.
+func SecurableTypeFor(kind ObjectKind) catalog.SecurableType {
+	return securableTypes[kind]
+}
+
+// TableKind distinguishes views from tables.
+func TableKind(t catalog.TableInfo) ObjectKind {
+	switch t.TableType {
+	case catalog.TableTypeView, catalog.TableTypeMaterializedView, catalog.TableTypeMetricView:
+		return KindView
+	default:
+		return KindTable
+	}
+}
+
+// ParseSecurableType accepts either an object kind ("model", "view",
+// "external-location") or a grants API securable type ("TABLE") and returns
+// the securable type to send to the grants API.
+func ParseSecurableType(s string) (string, error) {
+	key := strings.ToLower(strings.NewReplacer("-", "_", " ", "_").Replace(strings.TrimSpace(s)))
+	if t, ok := securableTypes[ObjectKind(key)]; ok {
+		return string(t), nil
+	}
+
+	upper := strings.ToUpper(key)
+	var st catalog.SecurableType
+	for _, v := range st.Values() {
+		if string(v) == upper {
+			return upper, nil
+		}
+	}
+	return "", fmt.Errorf("unknown securable type %q", s)
+}
@@ -0,0 +1,83 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+func TestSecurableTypeFor(t *testing.T) {
+	tests := []struct {
+		kind ObjectKind
+		want catalog.SecurableType
+	}{
+		{KindMetastore, catalog.SecurableTypeMetastore},
+		{KindCatalog, catalog.SecurableTypeCatalog},
+		{KindSchema, catalog.SecurableTypeSchema},
+		{KindTable, catalog.SecurableTypeTable},
+		{KindView, catalog.SecurableTypeTable},
+		{KindVolume, catalog.SecurableTypeVolume},
+		{KindFunction, catalog.SecurableTypeFunction},
+		{KindModel, catalog.SecurableTypeFunction},
+		{KindConnection, catalog.SecurableTypeConnection},
+		{KindExternalLocation, catalog.SecurableTypeExternalLocation},
+		{KindStorageCredential, catalog.SecurableTypeStorageCredential},
+	}
+
+	if len(tests) != len(securableTypes) {
+		t.Fatalf("test covers %d kinds, mapping has %d", len(tests), len(securableTypes))
+	}
+	for _, tt := range tests {
+		if got := SecurableTypeFor(tt.kind); got != tt.want {
+			t.Errorf("SecurableTypeFor(%s) = %s, want %s", tt.kind, got, tt.want)
+		}
+	}
+}
+
+func TestTableKind(t *testing.T) {
+	tests := []struct {
+		tableType catalog.TableType
+		want      ObjectKind
+	}{
+		{catalog.TableTypeManaged, KindTable},
+		{catalog.TableTypeExternal, KindTable},
+		{catalog.TableTypeStreamingTable, KindTable},
+		{catalog.TableTypeView, KindView},
+		{catalog.TableTypeMaterializedView, KindView},
+		{catalog.TableTypeMetricView, KindView},
+	}
+
+	for _, tt := range tests {
+		if got := TableKind(catalog.TableInfo{TableType: tt.tableType}); got != tt.want {
+			t.Errorf("TableKind(%s) = %s, want %s", tt.tableType, got, tt.want)
+		}
+	}
+}
+
+func TestParseSecurableType(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"TABLE", "TABLE", false},
+		{"table", "TABLE", false},
+		{"view", "TABLE", false},
+		{"model", "FUNCTION", false},
+		{"external-location", "EXTERNAL_LOCATION", false},
+		{"storage_credential", "STORAGE_CREDENTIAL", false},
+		{"SHARE", "SHARE", false},
+		{"notathing", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSecurableType(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSecurableType(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSecurableType(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
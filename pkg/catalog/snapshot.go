@@ -0,0 +1,233 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+// SnapshotVersion is the current snapshot file format version.
+const SnapshotVersion = 1
+
+// Snapshot is the structural metadata of a metastore at one point in time.
+type Snapshot struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Host      string    `json:"host,omitempty"`
+	// WithGrants records whether direct grants were collected.
+	WithGrants bool           `json:"with_grants"`
+	Catalogs   []CatalogModel `json:"catalogs"`
+}
+
+// CatalogModel describes one catalog and its schemas.
+type CatalogModel struct {
+	Name    string            `json:"name"`
+	Owner   string            `json:"owner,omitempty"`
+	Comment string            `json:"comment,omitempty"`
+	Grants  []PrincipalGrants `json:"grants,omitempty"`
+	Schemas []SchemaModel     `json:"schemas"`
+}
+
+// CatalogNames returns the names of the catalogs in the snapshot.
+func (s *Snapshot) CatalogNames() []string {
+	names := make([]string, len(s.Catalogs))
+	for i, c := range s.Catalogs {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// TakeSnapshot records the given catalogs, or every catalog except system
+// and internal ones when names is empty. Named catalogs that do not exist are
+// left out. progress, if not nil, is called before each catalog is read.
+func TakeSnapshot(ctx context.Context, w *databricks.WorkspaceClient, names []string, withGrants bool, progress func(catalogName string)) (*Snapshot, error) {
+	var catalogs []catalog.CatalogInfo
+	if len(names) == 0 {
+		all, err := ListCatalogs(ctx, w)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range all {
+			if c.CatalogType == catalog.CatalogTypeSystemCatalog || c.CatalogType == catalog.CatalogTypeInternalCatalog {
+				continue
+			}
+			catalogs = append(catalogs, c)
+		}
+	} else {
+		for _, n := range names {
+			c, err := GetCatalog(ctx, w, n)
+			if errors.Is(err, apierr.ErrNotFound) {
+				// A catalog dropped since the names were recorded is absent,
+				// which a diff reports as removed.
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get catalog %s: %w", n, err)
+			}
+			catalogs = append(catalogs, *c)
+		}
+	}
+
+	snap := &Snapshot{Version: SnapshotVersion, CreatedAt: time.Now().UTC(), Host: w.Config.Host, WithGrants: withGrants}
+	for _, c := range catalogs {
+		if progress != nil {
+			progress(c.Name)
+		}
+		cm := CatalogModel{Name: c.Name, Owner: c.Owner, Comment: c.Comment}
+		if withGrants {
+			grants, err := directGrants(ctx, w, string(catalog.SecurableTypeCatalog), c.Name)
+			if err != nil {
+				return nil, err
+			}
+			cm.Grants = grants
+		}
+		m, err := LoadScopeModel(ctx, w, c.Name, withGrants)
+		if err != nil {
+			return nil, err
+		}
+		cm.Schemas = m.Schemas
+		snap.Catalogs = append(snap.Catalogs, cm)
+	}
+	sort.Slice(snap.Catalogs, func(i, j int) bool { return snap.Catalogs[i].Name < snap.Catalogs[j].Name })
+	return snap, nil
+}
+
+// LoadSnapshot reads and validates a snapshot file.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	if s.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d in %s (expected %d)", s.Version, path, SnapshotVersion)
+	}
+	return &s, nil
+}
+
+// SaveSnapshot writes a snapshot to disk.
+func SaveSnapshot(path string, s *Snapshot) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// DiffSnapshots compares two snapshots catalog by catalog.
+func DiffSnapshots(a, b *Snapshot) []Change {
+	var d differ
+	ac := make(map[string]CatalogModel)
+	bc := make(map[string]CatalogModel)
+	names := make(map[string]bool)
+	for _, c := range a.Catalogs {
+		ac[c.Name] = c
+		names[c.Name] = true
+	}
+	for _, c := range b.Catalogs {
+		bc[c.Name] = c
+		names[c.Name] = true
+	}
+	for _, n := range sortedKeys(names) {
+		cpath := "/catalogs/" + escapePointer(n)
+		l, inA := ac[n]
+		r, inB := bc[n]
+		switch {
+		case !inB:
+			d.add("remove", cpath, nil, l)
+		case !inA:
+			d.add("add", cpath, r, nil)
+		default:
+			d.value(cpath+"/owner", l.Owner, r.Owner)
+			d.value(cpath+"/comment", l.Comment, r.Comment)
+			d.grants(cpath+"/grants", l.Grants, r.Grants)
+			d.schemas(cpath, l.Schemas, r.Schemas)
+		}
+	}
+	return d.changes
+}
+
+// RenderSnapshot renders a snapshot as indented text lines for a unified diff.
+func RenderSnapshot(s *Snapshot) []string {
+	var lines []string
+	for _, c := range s.Catalogs {
+		lines = append(lines, "catalog "+c.Name)
+		lines = append(lines, renderAttributes("  ", c.Owner, c.Comment, nil, c.Grants)...)
+		lines = append(lines, renderSchemas("  ", c.Schemas)...)
+	}
+	return lines
+}
+
+// IsBreaking reports whether a change can break existing readers or writers:
+// a removed catalog, schema, table or column, a changed table or column type,
+// a column that became NOT NULL, or privileges that were revoked.
+func IsBreaking(c Change) bool {
+	parts := strings.Split(c.Path, "/")
+	if len(parts) < 3 {
+		return false
+	}
+	kind, parent, field := parts[len(parts)-3], parts[len(parts)-2], parts[len(parts)-1]
+	switch {
+	case c.Op == "remove" && (parent == "catalogs" || parent == "schemas" || parent == "tables" || parent == "columns"):
+		return true
+	case c.Op == "replace" && field == "type" && (kind == "tables" || kind == "columns"):
+		return true
+	case c.Op == "replace" && field == "nullable" && kind == "columns":
+		return c.Value == false
+	case parent == "grants" && c.Op != "add":
+		return len(revokedPrivileges(c)) > 0
+	}
+	return false
+}
+
+// revokedPrivileges returns the privileges held before a grant change but not
+// after it.
+func revokedPrivileges(c Change) []string {
+	old, _ := c.Old.([]string)
+	now, _ := c.Value.([]string)
+	kept := make(map[string]bool)
+	for _, p := range now {
+		kept[p] = true
+	}
+	var revoked []string
+	for _, p := range old {
+		if !kept[p] {
+			revoked = append(revoked, p)
+		}
+	}
+	return revoked
+}
+
+// MatchesAny reports whether a change path matches one of the patterns. A
+// pattern is matched against the path with path.Match, and also matches
+// everything below it, e.g. /catalogs/dev or /catalogs/*/schemas/tmp_*.
+func MatchesAny(changePath string, patterns []string) bool {
+	for _, p := range patterns {
+		p = strings.TrimSuffix(p, "/")
+		segments := strings.Count(p, "/")
+		parts := strings.Split(changePath, "/")
+		if len(parts) <= segments {
+			continue
+		}
+		if ok, _ := path.Match(p, strings.Join(parts[:segments+1], "/")); ok {
+			return true
+		}
+	}
+	return false
+}
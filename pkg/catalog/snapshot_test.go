@@ -0,0 +1,139 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go"
+)
+
+func testSnapshot() *Snapshot {
+	return &Snapshot{Version: SnapshotVersion, CreatedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Catalogs: []CatalogModel{{
+		Name:   "main",
+		Owner:  "admins",
+		Grants: []PrincipalGrants{{Principal: "analysts", Privileges: []string{"SELECT", "USE_CATALOG"}}},
+		Schemas: []SchemaModel{{
+			Name: "sales",
+			Tables: []TableModel{{Name: "orders", Type: "MANAGED", Columns: []ColumnModel{
+				{Name: "id", Type: "bigint"},
+				{Name: "note", Type: "string", Nullable: true},
+			}}},
+		}},
+	}}}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snap.json")
+	if err := SaveSnapshot(path, testSnapshot()); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes := DiffSnapshots(testSnapshot(), loaded); len(changes) != 0 {
+		t.Errorf("round trip changed snapshot: %+v", changes)
+	}
+}
+
+func TestDiffSnapshotsBreaking(t *testing.T) {
+	a := testSnapshot()
+	b := testSnapshot()
+	b.Catalogs[0].Owner = "platform"
+	b.Catalogs[0].Grants[0].Privileges = []string{"USE_CATALOG"}
+	cols := b.Catalogs[0].Schemas[0].Tables[0].Columns
+	cols[0].Type = "int"
+	cols[1].Nullable = false
+	cols = append(cols, ColumnModel{Name: "region", Type: "string", Nullable: true})
+	b.Catalogs[0].Schemas[0].Tables[0].Columns = cols
+	b.Catalogs = append(b.Catalogs, CatalogModel{Name: "new"})
+
+	want := map[string]bool{
+		"/catalogs/main/owner":                                             false,
+		"/catalogs/main/grants/analysts":                                   true,
+		"/catalogs/main/schemas/sales/tables/orders/columns/id/type":       true,
+		"/catalogs/main/schemas/sales/tables/orders/columns/note/nullable": true,
+		"/catalogs/main/schemas/sales/tables/orders/columns/region":        false,
+		"/catalogs/new": false,
+	}
+	changes := DiffSnapshots(a, b)
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes, want %d: %+v", len(changes), len(want), changes)
+	}
+	for _, c := range changes {
+		breaking, ok := want[c.Path]
+		if !ok {
+			t.Errorf("unexpected change %s %s", c.Op, c.Path)
+			continue
+		}
+		if IsBreaking(c) != breaking {
+			t.Errorf("IsBreaking(%s) = %v, want %v", c.Path, !breaking, breaking)
+		}
+	}
+
+	removed := DiffSnapshots(b, a)
+	for _, c := range removed {
+		if c.Path == "/catalogs/new" && !IsBreaking(c) {
+			t.Error("removed catalog should be breaking")
+		}
+	}
+}
+
+func TestMatchesAny(t *testing.T) {
+	tests := []struct {
+		path     string
+		patterns []string
+		want     bool
+	}{
+		{"/catalogs/dev/schemas/a/tables/t", []string{"/catalogs/dev"}, true},
+		{"/catalogs/prod/schemas/tmp_x/tables/t", []string{"/catalogs/*/schemas/tmp_*"}, true},
+		{"/catalogs/prod/schemas/sales", []string{"/catalogs/*/schemas/tmp_*"}, false},
+		{"/catalogs/prod", []string{"/catalogs/prod/schemas/x"}, false},
+		{"/catalogs/prod", nil, false},
+	}
+	for _, tt := range tests {
+		if got := MatchesAny(tt.path, tt.patterns); got != tt.want {
+			t.Errorf("MatchesAny(%q, %v) = %v, want %v", tt.path, tt.patterns, got, tt.want)
+		}
+	}
+}
+
+func TestTakeSnapshotDroppedCatalog(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/2.1/unity-catalog/catalogs/main":
+			fmt.Fprint(rw, `{"name":"main","owner":"admins"}`)
+		case "/api/2.1/unity-catalog/schemas":
+			fmt.Fprint(rw, `{"schemas":[]}`)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(rw, `{"error_code":"CATALOG_DOES_NOT_EXIST","message":"Catalog does not exist."}`)
+		}
+	}))
+	defer srv.Close()
+	w, err := databricks.NewWorkspaceClient(&databricks.Config{Host: srv.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	baseline := &Snapshot{Version: SnapshotVersion, Catalogs: []CatalogModel{{Name: "gone"}, {Name: "main", Owner: "admins"}}}
+	current, err := TakeSnapshot(context.Background(), w, baseline.CatalogNames(), false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := DiffSnapshots(baseline, current)
+	if len(changes) != 1 || changes[0].Op != "remove" || changes[0].Path != "/catalogs/gone" {
+		t.Fatalf("changes = %+v, want remove /catalogs/gone", changes)
+	}
+	if !IsBreaking(changes[0]) {
+		t.Error("removed catalog is not breaking")
+	}
+	if !MatchesAny(changes[0].Path, []string{"/catalogs/gone"}) {
+		t.Error("--allow /catalogs/gone does not accept the removal")
+	}
+}
@@ -0,0 +1,33 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+// ListTables retrieves all tables and views in a specific schema.
+func ListTables(ctx context.Context, w *databricks.WorkspaceClient, catalogName, schemaName string) ([]catalog.TableInfo, error) {
+	request := catalog.ListTablesRequest{
+		CatalogName: catalogName,
+		SchemaName:  schemaName,
+	}
+
+	it := w.Tables.List(ctx, request)
+	var all []catalog.TableInfo
+	for it.HasNext(ctx) {
+		t, err := it.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate tables: %w", err)
+		}
+		all = append(all, t)
+	}
+	return all, nil
+}
+
+// GetTable retrieves details for a specific table.
+func GetTable(ctx context.Context, w *databricks.WorkspaceClient, fullName string) (*catalog.TableInfo, error) {
+	return w.Tables.Get(ctx, catalog.GetTableRequest{FullName: fullName})
+}
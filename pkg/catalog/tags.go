@@ -0,0 +1,137 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+// TagEntityTypes lists the object types that can carry governance tags, as
+// the entity tag API names them.
+var TagEntityTypes = []string{"catalogs", "schemas", "tables", "columns", "volumes"}
+
+// Tag is one key/value tag; Value is empty for key-only tags.
+type Tag struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+// ParseTagEntityType accepts "table", "tables", "view" or "TABLE" and returns
+// the entity tag API type ("tables").
+func ParseTagEntityType(s string) (string, error) {
+	t := strings.ToLower(strings.TrimSpace(s))
+	if t == "view" || t == "views" {
+		return "tables", nil
+	}
+	if !strings.HasSuffix(t, "s") {
+		t += "s"
+	}
+	for _, v := range TagEntityTypes {
+		if v == t {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unknown tag entity type %q (use %s)", s, strings.Join(TagEntityTypes, ", "))
+}
+
+// ParseTag splits "key=value" into a tag; a bare "key" is a key-only tag.
+func ParseTag(s string) (Tag, error) {
+	key, value, _ := strings.Cut(s, "=")
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return Tag{}, fmt.Errorf("invalid tag %q: expected key or key=value", s)
+	}
+	return Tag{Key: key, Value: strings.TrimSpace(value)}, nil
+}
+
+// String formats a tag as key=value, or key for key-only tags.
+func (t Tag) String() string {
+	if t.Value == "" {
+		return t.Key
+	}
+	return t.Key + "=" + t.Value
+}
+
+// ListTags returns the tags on one entity sorted by key.
+func ListTags(ctx context.Context, w *databricks.WorkspaceClient, entityType, entityName string) ([]Tag, error) {
+	assignments, err := w.EntityTagAssignments.ListAll(ctx, catalog.ListEntityTagAssignmentsRequest{EntityType: entityType, EntityName: entityName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags of %s: %w", entityName, err)
+	}
+	tags := make([]Tag, len(assignments))
+	for i, a := range assignments {
+		tags[i] = Tag{Key: a.TagKey, Value: a.TagValue}
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+	return tags, nil
+}
+
+// ListColumnTags returns the tags of every column of a table that has any,
+// keyed by column name. Columns are read concurrently.
+func ListColumnTags(ctx context.Context, w *databricks.WorkspaceClient, tableName string, columns []string) (map[string][]Tag, error) {
+	const concurrency = 8
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		result   = make(map[string][]Tag)
+		sem      = make(chan struct{}, concurrency)
+	)
+	for _, col := range columns {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(col string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			tags, err := ListTags(ctx, w, "columns", tableName+"."+col)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			if len(tags) > 0 {
+				result[col] = tags
+			}
+		}(col)
+	}
+	wg.Wait()
+	return result, firstErr
+}
+
+// SetTag assigns a tag, replacing the value if the key is already set.
+func SetTag(ctx context.Context, w *databricks.WorkspaceClient, entityType, entityName string, tag Tag) error {
+	assignment := catalog.EntityTagAssignment{EntityType: entityType, EntityName: entityName, TagKey: tag.Key, TagValue: tag.Value}
+	_, err := w.EntityTagAssignments.Create(ctx, catalog.CreateEntityTagAssignmentRequest{TagAssignment: assignment})
+	if errors.Is(err, apierr.ErrResourceConflict) {
+		_, err = w.EntityTagAssignments.Update(ctx, catalog.UpdateEntityTagAssignmentRequest{
+			EntityType:    entityType,
+			EntityName:    entityName,
+			TagKey:        tag.Key,
+			TagAssignment: assignment,
+			UpdateMask:    "tag_value",
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set tag %s on %s: %w", tag.Key, entityName, err)
+	}
+	return nil
+}
+
+// UnsetTag removes a tag from an entity.
+func UnsetTag(ctx context.Context, w *databricks.WorkspaceClient, entityType, entityName, key string) error {
+	err := w.EntityTagAssignments.Delete(ctx, catalog.DeleteEntityTagAssignmentRequest{EntityType: entityType, EntityName: entityName, TagKey: key})
+	if err != nil {
+		return fmt.Errorf("failed to unset tag %s on %s: %w", key, entityName, err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package catalog
+
+import "testing"
+
+func TestParseTagEntityType(t *testing.T) {
+	tests := map[string]string{
+		"table":   "tables",
+		"TABLE":   "tables",
+		"view":    "tables",
+		"columns": "columns",
+		"catalog": "catalogs",
+		"Volume":  "volumes",
+	}
+	for in, want := range tests {
+		if got, err := ParseTagEntityType(in); err != nil || got != want {
+			t.Errorf("ParseTagEntityType(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseTagEntityType("function"); err == nil {
+		t.Error("expected an error for function")
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Tag
+	}{
+		{"pii=email", Tag{"pii", "email"}},
+		{"certified", Tag{"certified", ""}},
+		{" owner = team=data ", Tag{"owner", "team=data"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseTag(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseTag(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+		if tt.want.Value == "" && got.String() != tt.want.Key {
+			t.Errorf("String() = %q, want %q", got.String(), tt.want.Key)
+		}
+	}
+	if _, err := ParseTag("=x"); err == nil {
+		t.Error("expected an error for an empty key")
+	}
+}
@@ -0,0 +1,124 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+// Securable identifies a Unity Catalog object by the securable type used by the
+// grants API and its full name.
+type Securable struct {
+	Kind     ObjectKind `json:"kind,omitempty" yaml:"-"`
+	Type     string     `json:"type" yaml:"type"`
+	FullName string     `json:"name" yaml:"name"`
+	Owner    string     `json:"owner,omitempty" yaml:"-"`
+}
+
+func newSecurable(kind ObjectKind, fullName, owner string) Securable {
+	return Securable{Kind: kind, Type: string(SecurableTypeFor(kind)), FullName: fullName, Owner: owner}
+}
+
+// ParseScope splits a "catalog" or "catalog.schema" scope into its parts.
+func ParseScope(scope string) (catalogName, schemaName string, err error) {
+	parts := strings.Split(scope, ".")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		return parts[0], "", nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return parts[0], parts[1], nil
+	default:
+		return "", "", fmt.Errorf("invalid scope %q: expected <catalog> or <catalog>.<schema>", scope)
+	}
+}
+
+// WalkSecurables visits the catalog or schema named by scope and every schema,
+// table, volume, function and registered model below it, calling fn for each.
+// information_schema is skipped as it is managed by the system.
+func WalkSecurables(ctx context.Context, w *databricks.WorkspaceClient, scope string, fn func(Securable) error) error {
+	catalogName, schemaName, err := ParseScope(scope)
+	if err != nil {
+		return err
+	}
+
+	var schemas []catalog.SchemaInfo
+	if schemaName == "" {
+		cat, err := GetCatalog(ctx, w, catalogName)
+		if err != nil {
+			return fmt.Errorf("failed to get catalog %s: %w", catalogName, err)
+		}
+		if err := fn(newSecurable(KindCatalog, cat.Name, cat.Owner)); err != nil {
+			return err
+		}
+		schemas, err = ListSchemas(ctx, w, catalogName)
+		if err != nil {
+			return err
+		}
+	} else {
+		s, err := GetSchema(ctx, w, scope)
+		if err != nil {
+			return fmt.Errorf("failed to get schema %s: %w", scope, err)
+		}
+		schemas = []catalog.SchemaInfo{*s}
+	}
+
+	for _, s := range schemas {
+		if s.Name == "information_schema" {
+			continue
+		}
+		if err := walkSchema(ctx, w, s, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkSchema(ctx context.Context, w *databricks.WorkspaceClient, s catalog.SchemaInfo, fn func(Securable) error) error {
+	if err := fn(newSecurable(KindSchema, s.FullName, s.Owner)); err != nil {
+		return err
+	}
+
+	tables, err := ListTables(ctx, w, s.CatalogName, s.Name)
+	if err != nil {
+		return err
+	}
+	for _, t := range tables {
+		if err := fn(newSecurable(TableKind(t), t.FullName, t.Owner)); err != nil {
+			return err
+		}
+	}
+
+	vols, err := ListVolumes(ctx, w, s.CatalogName, s.Name)
+	if err != nil {
+		return err
+	}
+	for _, v := range vols {
+		if err := fn(newSecurable(KindVolume, v.FullName, v.Owner)); err != nil {
+			return err
+		}
+	}
+
+	funcs, err := ListFunctions(ctx, w, s.CatalogName, s.Name)
+	if err != nil {
+		return err
+	}
+	for _, f := range funcs {
+		if err := fn(newSecurable(KindFunction, f.FullName, f.Owner)); err != nil {
+			return err
+		}
+	}
+
+	models, err := ListModels(ctx, w, s.CatalogName, s.Name)
+	if err != nil {
+		return err
+	}
+	for _, m := range models {
+		if err := fn(newSecurable(KindModel, m.FullName, m.Owner)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+// Package coverage measures how well catalogs and schemas are documented:
+// comments on tables and columns, group ownership and required tags.
+package coverage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	pkgcatalog "dbx-explore/pkg/catalog"
+	"dbx-explore/pkg/identity"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+// Lookup resolves a principal name, returning nil if it does not exist.
+type Lookup func(ctx context.Context, name string) (*identity.Principal, error)
+
+// TagSource returns the tags in a catalog keyed by lowercased dotted entity
+// name, e.g. query.CatalogTags bound to a warehouse.
+type TagSource func(ctx context.Context, catalogName string) (map[string]map[string]string, error)
+
+// Counts are the raw numbers behind a score.
+type Counts struct {
+	Tables           int `json:"tables"`
+	TablesCommented  int `json:"tables_commented"`
+	Columns          int `json:"columns"`
+	ColumnsCommented int `json:"columns_commented"`
+	// Owners counts the owned objects: the scope itself and everything in it.
+	Owners      int `json:"owners"`
+	GroupOwners int `json:"group_owners"`
+	// TablesTagged counts tables carrying every required tag.
+	TablesTagged int `json:"tables_tagged"`
+}
+
+func (c *Counts) add(o Counts) {
+	c.Tables += o.Tables
+	c.TablesCommented += o.TablesCommented
+	c.Columns += o.Columns
+	c.ColumnsCommented += o.ColumnsCommented
+	c.Owners += o.Owners
+	c.GroupOwners += o.GroupOwners
+	c.TablesTagged += o.TablesTagged
+}
+
+// Score is the coverage of one catalog or schema. Percentages are 0-100;
+// RequiredTags is nil when no tags are required. Score is the mean of the
+// percentages.
+type Score struct {
+	Scope          string   `json:"scope"`
+	Level          string   `json:"level"`
+	Counts         Counts   `json:"counts"`
+	TableComments  float64  `json:"table_comments_pct"`
+	ColumnComments float64  `json:"column_comments_pct"`
+	GroupOwners    float64  `json:"group_owners_pct"`
+	RequiredTags   *float64 `json:"required_tags_pct,omitempty"`
+	Score          float64  `json:"score"`
+}
+
+// Report holds the scores of every catalog and schema, each ranked from best
+// to worst.
+type Report struct {
+	CollectedAt  time.Time `json:"collected_at"`
+	RequiredTags []string  `json:"required_tags,omitempty"`
+	Catalogs     []Score   `json:"catalogs"`
+	Schemas      []Score   `json:"schemas"`
+}
+
+// CatalogInput is everything coverage needs to know about one catalog.
+type CatalogInput struct {
+	Info    catalog.CatalogInfo
+	Schemas []SchemaInput
+	// Tags is keyed by lowercased dotted entity name.
+	Tags map[string]map[string]string
+}
+
+// SchemaInput is one schema and its tables.
+type SchemaInput struct {
+	Info   catalog.SchemaInfo
+	Tables []catalog.TableInfo
+}
+
+// Collect reads the given catalogs and scores them. tags may be nil when no
+// tags are required.
+func Collect(ctx context.Context, w *databricks.WorkspaceClient, catalogs, required []string, tags TagSource, lookup Lookup, progress func(string)) (*Report, error) {
+	var inputs []CatalogInput
+	owners := map[string]bool{}
+	for _, name := range catalogs {
+		if progress != nil {
+			progress(name)
+		}
+		in, err := collectCatalog(ctx, w, name, len(required) > 0, tags)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, *in)
+		for _, o := range in.owners() {
+			owners[o] = true
+		}
+	}
+
+	groups := map[string]bool{}
+	for o := range owners {
+		p, err := lookup(ctx, o)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up owner %s: %w", o, err)
+		}
+		groups[o] = p != nil && p.Kind == identity.KindGroup
+	}
+	return Build(inputs, required, func(owner string) bool { return groups[owner] }), nil
+}
+
+func collectCatalog(ctx context.Context, w *databricks.WorkspaceClient, name string, withTags bool, tags TagSource) (*CatalogInput, error) {
+	info, err := pkgcatalog.GetCatalog(ctx, w, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get catalog %s: %w", name, err)
+	}
+	in := &CatalogInput{Info: *info}
+	if withTags && tags != nil {
+		if in.Tags, err = tags(ctx, name); err != nil {
+			return nil, fmt.Errorf("failed to read tags of %s: %w", name, err)
+		}
+	}
+
+	schemas, err := pkgcatalog.ListSchemas(ctx, w, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range schemas {
+		if s.Name == "information_schema" {
+			continue
+		}
+		tables, err := pkgcatalog.ListTables(ctx, w, s.CatalogName, s.Name)
+		if err != nil {
+			return nil, err
+		}
+		in.Schemas = append(in.Schemas, SchemaInput{Info: s, Tables: tables})
+	}
+	return in, nil
+}
+
+// owners returns every distinct owner in the catalog.
+func (in *CatalogInput) owners() []string {
+	seen := map[string]bool{}
+	var out []string
+	add := func(o string) {
+		if o != "" && !seen[o] {
+			seen[o] = true
+			out = append(out, o)
+		}
+	}
+	add(in.Info.Owner)
+	for _, s := range in.Schemas {
+		add(s.Info.Owner)
+		for _, t := range s.Tables {
+			add(t.Owner)
+		}
+	}
+	return out
+}
+
+// Build scores catalogs from collected metadata. isGroup reports whether an
+// owner is a group.
+func Build(catalogs []CatalogInput, required []string, isGroup func(string) bool) *Report {
+	r := &Report{CollectedAt: time.Now().UTC(), RequiredTags: required}
+	ownedBy := func(c *Counts, owner string) {
+		c.Owners++
+		if owner != "" && isGroup(owner) {
+			c.GroupOwners++
+		}
+	}
+
+	for _, in := range catalogs {
+		var total Counts
+		ownedBy(&total, in.Info.Owner)
+		for _, s := range in.Schemas {
+			var c Counts
+			ownedBy(&c, s.Info.Owner)
+			for _, t := range s.Tables {
+				c.Tables++
+				if strings.TrimSpace(t.Comment) != "" {
+					c.TablesCommented++
+				}
+				for _, col := range t.Columns {
+					c.Columns++
+					if strings.TrimSpace(col.Comment) != "" {
+						c.ColumnsCommented++
+					}
+				}
+				ownedBy(&c, t.Owner)
+				if hasTags(in.Tags[strings.ToLower(t.FullName)], required) {
+					c.TablesTagged++
+				}
+			}
+			total.add(c)
+			r.Schemas = append(r.Schemas, score(s.Info.FullName, "schema", c, len(required) > 0))
+		}
+		r.Catalogs = append(r.Catalogs, score(in.Info.Name, "catalog", total, len(required) > 0))
+	}
+	rank(r.Catalogs)
+	rank(r.Schemas)
+	return r
+}
+
+func hasTags(tags map[string]string, required []string) bool {
+	for _, key := range required {
+		if _, ok := tags[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func score(scope, level string, c Counts, withTags bool) Score {
+	s := Score{
+		Scope:          scope,
+		Level:          level,
+		Counts:         c,
+		TableComments:  percent(c.TablesCommented, c.Tables),
+		ColumnComments: percent(c.ColumnsCommented, c.Columns),
+		GroupOwners:    percent(c.GroupOwners, c.Owners),
+	}
+	sum, n := s.TableComments+s.ColumnComments+s.GroupOwners, 3.0
+	if withTags {
+		tags := percent(c.TablesTagged, c.Tables)
+		s.RequiredTags = &tags
+		sum, n = sum+tags, n+1
+	}
+	s.Score = round(sum / n)
+	return s
+}
+
+// percent treats an empty denominator as fully covered: there is nothing left
+// to document.
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return round(100 * float64(n) / float64(total))
+}
+
+func round(f float64) float64 {
+	return math.Round(f*10) / 10
+}
+
+// rank sorts scores from best to worst, then by name.
+func rank(scores []Score) {
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].Scope < scores[j].Scope
+	})
+}
@@ -0,0 +1,77 @@
+package coverage
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+func testCatalog() CatalogInput {
+	return CatalogInput{
+		Info: catalog.CatalogInfo{Name: "main", Owner: "data-platform"},
+		Schemas: []SchemaInput{
+			{
+				Info: catalog.SchemaInfo{FullName: "main.sales", Owner: "sales-team"},
+				Tables: []catalog.TableInfo{
+					{FullName: "main.sales.orders", Owner: "sales-team", Comment: "One row per order", Columns: []catalog.ColumnInfo{
+						{Name: "id", Comment: "Order id"}, {Name: "amount", Comment: " "},
+					}},
+					{FullName: "main.sales.refunds", Owner: "jane@example.com", Columns: []catalog.ColumnInfo{{Name: "id"}}},
+				},
+			},
+			{Info: catalog.SchemaInfo{FullName: "main.empty", Owner: "data-platform"}},
+		},
+		Tags: map[string]map[string]string{
+			"main.sales.orders":  {"domain": "sales", "pii": ""},
+			"main.sales.refunds": {"domain": "sales"},
+		},
+	}
+}
+
+func isGroup(owner string) bool { return owner != "jane@example.com" }
+
+func TestBuild(t *testing.T) {
+	r := Build([]CatalogInput{testCatalog()}, nil, isGroup)
+
+	if len(r.Schemas) != 2 || r.Schemas[0].Scope != "main.empty" {
+		t.Fatalf("schemas = %+v, want main.empty ranked first", r.Schemas)
+	}
+	sales := r.Schemas[1]
+	want := Counts{Tables: 2, TablesCommented: 1, Columns: 3, ColumnsCommented: 1, Owners: 3, GroupOwners: 2, TablesTagged: 2}
+	if sales.Counts != want {
+		t.Errorf("counts = %+v, want %+v", sales.Counts, want)
+	}
+	if sales.TableComments != 50 || sales.ColumnComments != 33.3 || sales.GroupOwners != 66.7 {
+		t.Errorf("percentages = %v/%v/%v", sales.TableComments, sales.ColumnComments, sales.GroupOwners)
+	}
+	if sales.RequiredTags != nil {
+		t.Error("RequiredTags should be nil without required tags")
+	}
+	if sales.Score != 50 {
+		t.Errorf("score = %v, want 50", sales.Score)
+	}
+
+	cat := r.Catalogs[0]
+	if cat.Counts.Owners != 5 || cat.Counts.GroupOwners != 4 || cat.Counts.Tables != 2 {
+		t.Errorf("catalog counts = %+v", cat.Counts)
+	}
+}
+
+func TestBuildRequiredTags(t *testing.T) {
+	r := Build([]CatalogInput{testCatalog()}, []string{"domain", "pii"}, isGroup)
+	var sales Score
+	for _, s := range r.Schemas {
+		if s.Scope == "main.sales" {
+			sales = s
+		}
+	}
+	if sales.RequiredTags == nil || *sales.RequiredTags != 50 {
+		t.Fatalf("RequiredTags = %v, want 50", sales.RequiredTags)
+	}
+	if sales.Counts.TablesTagged != 1 {
+		t.Errorf("TablesTagged = %d, want 1", sales.Counts.TablesTagged)
+	}
+	if sales.Score != 50 {
+		t.Errorf("score = %v, want 50", sales.Score)
+	}
+}
@@ -0,0 +1,212 @@
+// Package diff computes line diffs and renders them as unified diffs.
+package diff
+
+import "fmt"
+
+// Op is the kind of an edit.
+type Op int
+
+const (
+	Equal Op = iota
+	Delete
+	Insert
+)
+
+// Edit is one line of an edit script.
+type Edit struct {
+	Op   Op
+	Line string
+}
+
+// Lines returns the shortest edit script turning a into b. It uses the
+// linear-space variant of Myers' algorithm, which splits the problem at the
+// middle snake of an optimal path, so memory stays O(len(a)+len(b)) however
+// many differences there are.
+func Lines(a, b []string) []Edit {
+	if len(a)+len(b) == 0 {
+		return nil
+	}
+	size := (len(a)+len(b)+1)/2 + 1
+	l := myers{
+		a:      a,
+		b:      b,
+		vf:     make([]int, 2*size+1),
+		vb:     make([]int, 2*size+1),
+		offset: size,
+		edits:  make([]Edit, 0, len(a)+len(b)),
+	}
+	l.compare(0, len(a), 0, len(b))
+	return l.edits
+}
+
+// myers holds the state shared by the recursive steps of Lines.
+type myers struct {
+	a, b   []string
+	vf, vb []int // furthest x per diagonal, forward and backward
+	offset int
+	edits  []Edit
+}
+
+// compare appends the edit script for a[a0:a1] -> b[b0:b1].
+func (l *myers) compare(a0, a1, b0, b1 int) {
+	for a0 < a1 && b0 < b1 && l.a[a0] == l.b[b0] {
+		l.edits = append(l.edits, Edit{Equal, l.a[a0]})
+		a0++
+		b0++
+	}
+	suffix := 0
+	for a0 < a1 && b0 < b1 && l.a[a1-1] == l.b[b1-1] {
+		a1--
+		b1--
+		suffix++
+	}
+
+	switch {
+	case a0 == a1:
+		for _, line := range l.b[b0:b1] {
+			l.edits = append(l.edits, Edit{Insert, line})
+		}
+	case b0 == b1:
+		for _, line := range l.a[a0:a1] {
+			l.edits = append(l.edits, Edit{Delete, line})
+		}
+	default:
+		x, y, u, v := l.middleSnake(a0, a1, b0, b1)
+		l.compare(a0, x, b0, y)
+		for _, line := range l.a[x:u] {
+			l.edits = append(l.edits, Edit{Equal, line})
+		}
+		l.compare(u, a1, v, b1)
+	}
+
+	for _, line := range l.a[a1 : a1+suffix] {
+		l.edits = append(l.edits, Edit{Equal, line})
+	}
+}
+
+// middleSnake runs the search from both ends of a[a0:a1] -> b[b0:b1] until
+// the paths meet and returns the snake (x, y) -> (u, v) where they do, in
+// absolute positions. Both sides of it cost fewer edits than the whole.
+func (l *myers) middleSnake(a0, a1, b0, b1 int) (x, y, u, v int) {
+	n, m := a1-a0, b1-b0
+	delta := n - m
+	odd := delta%2 != 0
+	vf, vb, off := l.vf, l.vb, l.offset
+	vf[off+1], vb[off+1] = 0, 0
+
+	for d := 0; d <= (n+m+1)/2; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && vf[off+k-1] < vf[off+k+1]) {
+				x = vf[off+k+1]
+			} else {
+				x = vf[off+k-1] + 1
+			}
+			y := x - k
+			x0, y0 := x, y
+			for x < n && y < m && l.a[a0+x] == l.b[b0+y] {
+				x++
+				y++
+			}
+			vf[off+k] = x
+			if kr := delta - k; odd && kr >= -(d-1) && kr <= d-1 && x+vb[off+kr] >= n {
+				return a0 + x0, b0 + y0, a0 + x, b0 + y
+			}
+		}
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && vb[off+k-1] < vb[off+k+1]) {
+				x = vb[off+k+1]
+			} else {
+				x = vb[off+k-1] + 1
+			}
+			y := x - k
+			x0, y0 := x, y
+			for x < n && y < m && l.a[a1-1-x] == l.b[b1-1-y] {
+				x++
+				y++
+			}
+			vb[off+k] = x
+			if kf := delta - k; !odd && kf >= -d && kf <= d && x+vf[off+kf] >= n {
+				return a1 - x, b1 - y, a1 - x0, b1 - y0
+			}
+		}
+	}
+	panic("diff: no middle snake")
+}
+
+// Unified renders the differences between a and b as a unified diff with
+// the given number of context lines. It returns nil when a and b are equal.
+func Unified(a, b []string, fromName, toName string, context int) []string {
+	edits := Lines(a, b)
+
+	// Line positions in a and b before each edit.
+	ai := make([]int, len(edits)+1)
+	bi := make([]int, len(edits)+1)
+	changed := false
+	for i, e := range edits {
+		ai[i+1], bi[i+1] = ai[i], bi[i]
+		if e.Op != Insert {
+			ai[i+1]++
+		}
+		if e.Op != Delete {
+			bi[i+1]++
+		}
+		changed = changed || e.Op != Equal
+	}
+	if !changed {
+		return nil
+	}
+
+	out := []string{"--- " + fromName, "+++ " + toName}
+	for i := 0; i < len(edits); {
+		for i < len(edits) && edits[i].Op == Equal {
+			i++
+		}
+		if i == len(edits) {
+			break
+		}
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		last := i
+		for j := i; j < len(edits); j++ {
+			if edits[j].Op != Equal {
+				last = j
+			} else if j-last > 2*context {
+				break
+			}
+		}
+		end := last + context + 1
+		if end > len(edits) {
+			end = len(edits)
+		}
+
+		out = append(out, fmt.Sprintf("@@ -%s +%s @@", hunkRange(ai[start], ai[end]), hunkRange(bi[start], bi[end])))
+		for _, e := range edits[start:end] {
+			switch e.Op {
+			case Equal:
+				out = append(out, " "+e.Line)
+			case Delete:
+				out = append(out, "-"+e.Line)
+			case Insert:
+				out = append(out, "+"+e.Line)
+			}
+		}
+		i = end
+	}
+	return out
+}
+
+// hunkRange formats a 1-based line range; empty ranges point at the line
+// before them, as in GNU diff.
+func hunkRange(from, to int) string {
+	if to-from == 1 {
+		return fmt.Sprintf("%d", from+1)
+	}
+	if to == from {
+		return fmt.Sprintf("%d,0", from)
+	}
+	return fmt.Sprintf("%d,%d", from+1, to-from)
+}
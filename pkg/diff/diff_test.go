@@ -0,0 +1,153 @@
+package diff
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	a := strings.Split("a b c e", " ")
+	b := strings.Split("a c d e", " ")
+	var got []string
+	for _, e := range Lines(a, b) {
+		got = append(got, [...]string{" ", "-", "+"}[e.Op]+e.Line)
+	}
+	if want := " a -b  c +d  e"; strings.Join(got, " ") != want {
+		t.Errorf("edits = %q, want %q", strings.Join(got, " "), want)
+	}
+	if Lines(nil, nil) != nil {
+		t.Error("expected no edits for empty input")
+	}
+}
+
+// checkEdits verifies that edits turn a into b and returns the number of
+// inserted and deleted lines.
+func checkEdits(t *testing.T, a, b []string, edits []Edit) int {
+	t.Helper()
+	var gotA, gotB []string
+	cost := 0
+	for _, e := range edits {
+		if e.Op != Insert {
+			gotA = append(gotA, e.Line)
+		}
+		if e.Op != Delete {
+			gotB = append(gotB, e.Line)
+		}
+		if e.Op != Equal {
+			cost++
+		}
+	}
+	if strings.Join(gotA, "\n") != strings.Join(a, "\n") || strings.Join(gotB, "\n") != strings.Join(b, "\n") {
+		t.Fatalf("edits do not turn %q into %q", a, b)
+	}
+	return cost
+}
+
+// editDistance is the textbook O(n*m) insert/delete distance.
+func editDistance(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				cur[j] = prev[j-1]
+			} else {
+				cur[j] = min(prev[j], cur[j-1]) + 1
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func TestLinesShortest(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	random := func() []string {
+		s := make([]string, r.Intn(12))
+		for i := range s {
+			s[i] = string(rune('a' + r.Intn(3)))
+		}
+		return s
+	}
+	for i := 0; i < 2000; i++ {
+		a, b := random(), random()
+		if got, want := checkEdits(t, a, b, Lines(a, b)), editDistance(a, b); got != want {
+			t.Fatalf("Lines(%q, %q) has %d edits, want %d", a, b, got, want)
+		}
+	}
+}
+
+func TestLinesLargeInput(t *testing.T) {
+	// 50,000 lines with every tenth one changed: the quadratic trace this
+	// replaced needed gigabytes here.
+	r := rand.New(rand.NewSource(1))
+	var a, b []string
+	for i := 0; i < 50000; i++ {
+		line := fmt.Sprintf("line %d", i)
+		switch {
+		case i%10 != 0:
+			a = append(a, line)
+			b = append(b, line)
+		case r.Intn(2) == 0:
+			a = append(a, line)
+		default:
+			b = append(b, line+" changed")
+		}
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	edits := Lines(a, b)
+	runtime.ReadMemStats(&after)
+
+	if got := checkEdits(t, a, b, edits); got != 5000 {
+		t.Errorf("got %d edits, want 5000", got)
+	}
+	if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 64<<20 {
+		t.Errorf("allocated %d MB", alloc>>20)
+	}
+}
+
+func TestUnified(t *testing.T) {
+	var a, b []string
+	for i := 1; i <= 20; i++ {
+		line := string(rune('a' + i - 1))
+		a = append(a, line)
+		if i != 3 && i != 18 {
+			b = append(b, line)
+		}
+	}
+	b = append(b, "z")
+
+	got := strings.Join(Unified(a, b, "left", "right", 2), "\n")
+	want := strings.Join([]string{
+		"--- left",
+		"+++ right",
+		"@@ -1,5 +1,4 @@",
+		" a",
+		" b",
+		"-c",
+		" d",
+		" e",
+		"@@ -16,5 +15,5 @@",
+		" p",
+		" q",
+		"-r",
+		" s",
+		" t",
+		"+z",
+	}, "\n")
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if Unified(a, a, "l", "r", 3) != nil {
+		t.Error("expected nil for equal input")
+	}
+}
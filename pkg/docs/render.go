@@ -0,0 +1,171 @@
+package docs
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// Format is the output format of a generated site.
+type Format string
+
+const (
+	FormatHTML     Format = "html"
+	FormatMarkdown Format = "markdown"
+)
+
+// SearchEntry is one entry of the client-side search index.
+type SearchEntry struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Comment string `json:"comment,omitempty"`
+	Tags    string `json:"tags,omitempty"`
+	URL     string `json:"url"`
+}
+
+// page is the data passed to every template.
+type page struct {
+	Site  *Site
+	Title string
+	Root  string
+	Page  interface{}
+}
+
+// executor is implemented by both html/template and text/template.
+type executor interface {
+	ExecuteTemplate(w io.Writer, name string, data interface{}) error
+}
+
+// Generate writes the site to dir and returns the number of pages written.
+func Generate(site *Site, dir string, format Format) (int, error) {
+	ext, exec, err := loadTemplates(format)
+	if err != nil {
+		return 0, err
+	}
+
+	g := &generator{site: site, dir: dir, ext: ext, exec: exec}
+	g.write("index", "Catalogs", "index", nil)
+	for _, c := range site.Catalogs {
+		g.write(c.Path, c.Name, "catalog", c)
+		g.index = append(g.index, SearchEntry{Kind: "catalog", Name: c.Name, Comment: c.Comment, Tags: tagText(c.Tags), URL: c.Path + ext})
+		for _, s := range c.Schemas {
+			g.write(s.Path, s.Catalog+"."+s.Name, "schema", s)
+			g.index = append(g.index, SearchEntry{Kind: "schema", Name: s.Catalog + "." + s.Name, Comment: s.Comment, Tags: tagText(s.Tags), URL: s.Path + ext})
+			for _, t := range s.Tables {
+				g.write(t.Path, t.FullName, "table", t)
+				g.index = append(g.index, SearchEntry{Kind: "table", Name: t.FullName, Comment: t.Comment, Tags: tagText(t.Tags), URL: t.Path + ext})
+				for _, col := range t.Columns {
+					url := t.Path + ext
+					if format == FormatHTML {
+						url += "#col-" + neturl.PathEscape(col.Name)
+					}
+					g.index = append(g.index, SearchEntry{Kind: "column", Name: t.FullName + "." + col.Name, Comment: col.Comment, Tags: tagText(col.Tags), URL: url})
+				}
+			}
+		}
+	}
+	g.writeAssets(format)
+	return g.pages, g.err
+}
+
+func loadTemplates(format Format) (string, executor, error) {
+	funcs := map[string]interface{}{
+		"join": strings.Join,
+		// cell keeps a value on one Markdown table row.
+		"cell": func(s string) string {
+			return strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ").Replace(s)
+		},
+	}
+	switch format {
+	case FormatHTML:
+		t, err := htmltemplate.New("site").Funcs(funcs).ParseFS(templates, "templates/site.html")
+		return ".html", t, err
+	case FormatMarkdown:
+		t, err := texttemplate.New("site").Funcs(funcs).ParseFS(templates, "templates/site.md")
+		return ".md", t, err
+	default:
+		return "", nil, fmt.Errorf("unknown format %q (use html or markdown)", format)
+	}
+}
+
+type generator struct {
+	site  *Site
+	dir   string
+	ext   string
+	exec  executor
+	index []SearchEntry
+	pages int
+	err   error
+}
+
+// write renders one page; the first error stops further writes.
+func (g *generator) write(path, title, kind string, data interface{}) {
+	if g.err != nil {
+		return
+	}
+	var buf bytes.Buffer
+	root := strings.Repeat("../", strings.Count(path, "/"))
+	if err := g.exec.ExecuteTemplate(&buf, kind+g.ext, page{Site: g.site, Title: title, Root: root, Page: data}); err != nil {
+		g.err = fmt.Errorf("failed to render %s: %w", path, err)
+		return
+	}
+	g.save(path+g.ext, buf.Bytes())
+	g.pages++
+}
+
+func (g *generator) save(name string, data []byte) {
+	if g.err != nil {
+		return
+	}
+	target := filepath.Join(g.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		g.err = fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+		return
+	}
+	if err := os.WriteFile(target, data, 0644); err != nil {
+		g.err = fmt.Errorf("failed to write %s: %w", target, err)
+	}
+}
+
+// writeAssets writes the search index, and for HTML the stylesheet and the
+// search script. The index is also written as a script so that search works
+// without a web server.
+func (g *generator) writeAssets(format Format) {
+	index, err := json.Marshal(g.index)
+	if err != nil {
+		g.err = fmt.Errorf("failed to encode search index: %w", err)
+		return
+	}
+	g.save("search-index.json", index)
+	if format != FormatHTML {
+		return
+	}
+	g.save("assets/search-index.js", []byte("window.SEARCH_INDEX = "+string(index)+";\n"))
+	for _, asset := range []string{"style.css", "search.js"} {
+		data, err := templates.ReadFile("templates/" + asset)
+		if err != nil {
+			g.err = err
+			return
+		}
+		g.save("assets/"+asset, data)
+	}
+}
+
+func tagText(tags []Tag) string {
+	parts := make([]string, len(tags))
+	for i, t := range tags {
+		parts[i] = t.Key + " " + t.Value
+	}
+	return strings.Join(parts, " ")
+}
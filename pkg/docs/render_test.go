@@ -0,0 +1,95 @@
+package docs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testSite() *Site {
+	orders := Table{
+		FullName: "main.sales.orders", Catalog: "main", Schema: "sales", Name: "orders", Type: "MANAGED",
+		Owner: "data-eng", Comment: "All orders | incl. returns",
+		Tags:       []Tag{{"pii", ""}},
+		Columns:    []Column{{Name: "id", Type: "bigint"}, {Name: "customer_id", Type: "bigint", Nullable: true, Comment: "Buyer"}},
+		PrimaryKey: []string{"id"},
+		ForeignKeys: []ForeignKey{
+			{Name: "fk_customer", Columns: []string{"customer_id"}, ParentTable: "main.sales.customers", ParentColumns: []string{"id"}},
+			{Name: "fk_region", Columns: []string{"region"}, ParentTable: "ref.geo.regions", ParentColumns: []string{"code"}},
+		},
+		Path: "main/sales/tables/orders",
+	}
+	customers := Table{FullName: "main.sales.customers", Catalog: "main", Schema: "sales", Name: "customers", Type: "MANAGED", Path: "main/sales/tables/customers"}
+	site := &Site{Title: "Data Dictionary", Generated: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Catalogs: []Catalog{{
+		Name: "main", Path: "main/index",
+		Schemas: []Schema{{Catalog: "main", Name: "sales", Path: "main/sales/index", Tables: []Table{customers, orders}}},
+	}}}
+	site.linkForeignKeys()
+	return site
+}
+
+func TestGenerateHTML(t *testing.T) {
+	dir := t.TempDir()
+	pages, err := Generate(testSite(), dir, FormatHTML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pages != 5 {
+		t.Errorf("pages = %d, want 5", pages)
+	}
+	for _, f := range []string{"index.html", "main/index.html", "main/sales/index.html", "assets/style.css", "assets/search.js", "assets/search-index.js"} {
+		if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+			t.Errorf("missing %s", f)
+		}
+	}
+
+	page, err := os.ReadFile(filepath.Join(dir, "main/sales/tables/orders.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<a href="../../../main/sales/tables/customers.html">main.sales.customers</a>`,
+		`→ ref.geo.regions (code)`,
+		`<link rel="stylesheet" href="../../../assets/style.css">`,
+		`<span class="tag">pii</span>`,
+	} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("orders.html missing %q", want)
+		}
+	}
+
+	var index []SearchEntry
+	data, _ := os.ReadFile(filepath.Join(dir, "search-index.json"))
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, e := range index {
+		if e.Kind == "column" && e.Name == "main.sales.orders.customer_id" {
+			found = e.URL == "main/sales/tables/orders.html#col-customer_id" && e.Comment == "Buyer"
+		}
+	}
+	if !found {
+		t.Errorf("search index has no entry for customer_id: %s", data)
+	}
+}
+
+func TestGenerateMarkdown(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Generate(testSite(), dir, FormatMarkdown); err != nil {
+		t.Fatal(err)
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "main/sales/index.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `| [orders](../../main/sales/tables/orders.md) | MANAGED | 2 | data-eng | All orders \| incl. returns |`; !strings.Contains(string(page), want) {
+		t.Errorf("sales/index.md missing %q:\n%s", want, page)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "assets")); !os.IsNotExist(err) {
+		t.Error("markdown output should not include HTML assets")
+	}
+}
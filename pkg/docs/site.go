@@ -0,0 +1,251 @@
+// Package docs builds a static data dictionary site from Unity Catalog
+// metadata.
+package docs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	pkgcatalog "dbx-explore/pkg/catalog"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+// Site is everything rendered into a data dictionary.
+type Site struct {
+	Title     string
+	Host      string
+	Generated time.Time
+	Catalogs  []Catalog
+}
+
+// Tag is a governed tag on a catalog, schema, table or column.
+type Tag struct {
+	Key   string
+	Value string
+}
+
+// Catalog is one catalog page.
+type Catalog struct {
+	Name    string
+	Owner   string
+	Comment string
+	Tags    []Tag
+	Schemas []Schema
+	Path    string
+}
+
+// Schema is one schema page.
+type Schema struct {
+	Catalog string
+	Name    string
+	Owner   string
+	Comment string
+	Tags    []Tag
+	Tables  []Table
+	Path    string
+}
+
+// Table is one table page.
+type Table struct {
+	FullName    string
+	Catalog     string
+	Schema      string
+	Name        string
+	Type        string
+	Owner       string
+	Comment     string
+	Created     string
+	Updated     string
+	Tags        []Tag
+	Columns     []Column
+	PrimaryKey  []string
+	ForeignKeys []ForeignKey
+	ExploreURL  string
+	LineageURL  string
+	Path        string
+}
+
+// Column is one row of a table's column list.
+type Column struct {
+	Name     string
+	Type     string
+	Nullable bool
+	Comment  string
+	Tags     []Tag
+}
+
+// ForeignKey references another table. ParentPath is set when the parent
+// table is part of the site.
+type ForeignKey struct {
+	Name          string
+	Columns       []string
+	ParentTable   string
+	ParentColumns []string
+	ParentPath    string
+}
+
+// TagSource returns the tags in a catalog keyed by lowercased dotted entity
+// name, e.g. "main.sales.orders.id".
+type TagSource func(ctx context.Context, catalogName string) (map[string]map[string]string, error)
+
+// Collect reads the given catalogs (optionally limited to one schema) into a
+// site. tags may be nil to leave tags out.
+func Collect(ctx context.Context, w *databricks.WorkspaceClient, catalogs []string, schemaName string, tags TagSource, progress func(string)) (*Site, error) {
+	site := &Site{Title: "Data Dictionary", Host: strings.TrimSuffix(w.Config.Host, "/"), Generated: time.Now()}
+	for _, name := range catalogs {
+		if progress != nil {
+			progress(name)
+		}
+		c, err := collectCatalog(ctx, w, site.Host, name, schemaName, tags)
+		if err != nil {
+			return nil, err
+		}
+		site.Catalogs = append(site.Catalogs, *c)
+	}
+	site.linkForeignKeys()
+	return site, nil
+}
+
+func collectCatalog(ctx context.Context, w *databricks.WorkspaceClient, host, name, schemaName string, tags TagSource) (*Catalog, error) {
+	info, err := pkgcatalog.GetCatalog(ctx, w, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get catalog %s: %w", name, err)
+	}
+	var tagMap map[string]map[string]string
+	if tags != nil {
+		if tagMap, err = tags(ctx, name); err != nil {
+			return nil, fmt.Errorf("failed to read tags of %s: %w", name, err)
+		}
+	}
+
+	c := &Catalog{Name: info.Name, Owner: info.Owner, Comment: info.Comment, Tags: tagsFor(tagMap, info.Name), Path: slug(info.Name) + "/index"}
+	var schemas []catalog.SchemaInfo
+	if schemaName != "" {
+		s, err := pkgcatalog.GetSchema(ctx, w, name+"."+schemaName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get schema %s.%s: %w", name, schemaName, err)
+		}
+		schemas = []catalog.SchemaInfo{*s}
+	} else if schemas, err = pkgcatalog.ListSchemas(ctx, w, name); err != nil {
+		return nil, err
+	}
+
+	for _, s := range schemas {
+		if s.Name == "information_schema" {
+			continue
+		}
+		schema := Schema{
+			Catalog: s.CatalogName,
+			Name:    s.Name,
+			Owner:   s.Owner,
+			Comment: s.Comment,
+			Tags:    tagsFor(tagMap, s.FullName),
+			Path:    slug(s.CatalogName) + "/" + slug(s.Name) + "/index",
+		}
+		tables, err := pkgcatalog.ListTables(ctx, w, s.CatalogName, s.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tables {
+			schema.Tables = append(schema.Tables, tableFor(t, tagMap, host))
+		}
+		sort.Slice(schema.Tables, func(i, j int) bool { return schema.Tables[i].Name < schema.Tables[j].Name })
+		c.Schemas = append(c.Schemas, schema)
+	}
+	sort.Slice(c.Schemas, func(i, j int) bool { return c.Schemas[i].Name < c.Schemas[j].Name })
+	return c, nil
+}
+
+func tableFor(t catalog.TableInfo, tagMap map[string]map[string]string, host string) Table {
+	d := pkgcatalog.DescribeTable(t)
+	explore := host + "/explore/data/" + strings.ReplaceAll(t.FullName, ".", "/")
+	table := Table{
+		FullName:   t.FullName,
+		Catalog:    t.CatalogName,
+		Schema:     t.SchemaName,
+		Name:       t.Name,
+		Type:       string(t.TableType),
+		Owner:      t.Owner,
+		Comment:    t.Comment,
+		Created:    formatMillis(t.CreatedAt),
+		Updated:    formatMillis(t.UpdatedAt),
+		Tags:       tagsFor(tagMap, t.FullName),
+		ExploreURL: explore,
+		LineageURL: explore + "?activeTab=lineage",
+		Path:       slug(t.CatalogName) + "/" + slug(t.SchemaName) + "/tables/" + slug(t.Name),
+	}
+	for _, col := range t.Columns {
+		table.Columns = append(table.Columns, Column{
+			Name:     col.Name,
+			Type:     col.TypeText,
+			Nullable: col.Nullable,
+			Comment:  col.Comment,
+			Tags:     tagsFor(tagMap, t.FullName+"."+col.Name),
+		})
+	}
+	if d.PrimaryKey != nil {
+		table.PrimaryKey = d.PrimaryKey.ChildColumns
+	}
+	for _, fk := range d.ForeignKeys {
+		table.ForeignKeys = append(table.ForeignKeys, ForeignKey{
+			Name:          fk.Name,
+			Columns:       fk.ChildColumns,
+			ParentTable:   fk.ParentTable,
+			ParentColumns: fk.ParentColumns,
+		})
+	}
+	return table
+}
+
+// linkForeignKeys points foreign keys at parent tables that are in the site.
+func (s *Site) linkForeignKeys() {
+	paths := make(map[string]string)
+	for _, c := range s.Catalogs {
+		for _, sc := range c.Schemas {
+			for _, t := range sc.Tables {
+				paths[strings.ToLower(t.FullName)] = t.Path
+			}
+		}
+	}
+	for ci := range s.Catalogs {
+		for si := range s.Catalogs[ci].Schemas {
+			tables := s.Catalogs[ci].Schemas[si].Tables
+			for ti := range tables {
+				for fi := range tables[ti].ForeignKeys {
+					fk := &tables[ti].ForeignKeys[fi]
+					fk.ParentPath = paths[strings.ToLower(fk.ParentTable)]
+				}
+			}
+		}
+	}
+}
+
+func tagsFor(tagMap map[string]map[string]string, entity string) []Tag {
+	m := tagMap[strings.ToLower(entity)]
+	tags := make([]Tag, 0, len(m))
+	for k, v := range m {
+		tags = append(tags, Tag{k, v})
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+	return tags
+}
+
+func formatMillis(ms int64) string {
+	if ms == 0 {
+		return ""
+	}
+	return time.UnixMilli(ms).Format("2006-01-02 15:04")
+}
+
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// slug turns an object name into a safe file name.
+func slug(name string) string {
+	return unsafeChars.ReplaceAllString(name, "_")
+}
@@ -0,0 +1,35 @@
+// Client-side search over window.SEARCH_INDEX, loaded from search-index.js so
+// that the site also works when opened from the file system.
+document.addEventListener("DOMContentLoaded", function () {
+  var input = document.getElementById("search");
+  var results = document.getElementById("results");
+  var index = window.SEARCH_INDEX || [];
+
+  function render(query) {
+    results.innerHTML = "";
+    var terms = query.toLowerCase().split(/\s+/).filter(Boolean);
+    if (!terms.length) return;
+    var shown = 0;
+    for (var i = 0; i < index.length && shown < 50; i++) {
+      var e = index[i];
+      var text = (e.name + " " + (e.comment || "") + " " + (e.tags || "")).toLowerCase();
+      if (!terms.every(function (t) { return text.indexOf(t) >= 0; })) continue;
+      var li = document.createElement("li");
+      var a = document.createElement("a");
+      a.href = window.SITE_ROOT + e.url;
+      var kind = document.createElement("span");
+      kind.className = "kind";
+      kind.textContent = e.kind;
+      a.appendChild(kind);
+      a.appendChild(document.createTextNode(e.name + (e.comment ? " — " + e.comment : "")));
+      li.appendChild(a);
+      results.appendChild(li);
+      shown++;
+    }
+  }
+
+  input.addEventListener("input", function () { render(input.value); });
+  input.addEventListener("keydown", function (ev) {
+    if (ev.key === "Escape") { input.value = ""; render(""); }
+  });
+});
@@ -0,0 +1,229 @@
+package files
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/client"
+	"github.com/databricks/databricks-sdk-go/service/files"
+)
+
+// Entry is a file or directory inside a volume.
+type Entry struct {
+	Path         string
+	Name         string
+	IsDirectory  bool
+	Size         int64
+	LastModified time.Time
+}
+
+// VolumePath builds the /Volumes/<catalog>/<schema>/<volume> root for a volume.
+func VolumePath(catalogName, schemaName, volumeName string) string {
+	return path.Join("/Volumes", catalogName, schemaName, volumeName)
+}
+
+// ValidatePath checks that p is an absolute path inside a UC volume.
+func ValidatePath(p string) error {
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+	if !strings.HasPrefix(p, "/Volumes/") || len(parts) < 4 {
+		return fmt.Errorf("invalid volume path %q: expected /Volumes/<catalog>/<schema>/<volume>/...", p)
+	}
+	return nil
+}
+
+// List returns the contents of a directory, directories first, then by name.
+func List(ctx context.Context, w *databricks.WorkspaceClient, dir string) ([]Entry, error) {
+	it := w.Files.ListDirectoryContents(ctx, files.ListDirectoryContentsRequest{DirectoryPath: dir})
+	var all []Entry
+	for it.HasNext(ctx) {
+		e, err := it.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+		}
+		all = append(all, Entry{
+			Path:         e.Path,
+			Name:         e.Name,
+			IsDirectory:  e.IsDirectory,
+			Size:         e.FileSize,
+			LastModified: time.UnixMilli(e.LastModified),
+		})
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].IsDirectory != all[j].IsDirectory {
+			return all[i].IsDirectory
+		}
+		return all[i].Name < all[j].Name
+	})
+	return all, nil
+}
+
+// Walk lists every file below dir recursively.
+func Walk(ctx context.Context, w *databricks.WorkspaceClient, dir string) ([]Entry, error) {
+	entries, err := List(ctx, w, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []Entry
+	for _, e := range entries {
+		if !e.IsDirectory {
+			all = append(all, e)
+			continue
+		}
+		sub, err := Walk(ctx, w, e.Path)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, sub...)
+	}
+	return all, nil
+}
+
+// Stat returns metadata for a file or directory, or nil if it does not exist.
+func Stat(ctx context.Context, w *databricks.WorkspaceClient, p string) (*Entry, error) {
+	meta, err := w.Files.GetMetadata(ctx, files.GetMetadataRequest{FilePath: p})
+	if err == nil {
+		modified, _ := http.ParseTime(meta.LastModified)
+		return &Entry{Path: p, Name: path.Base(p), Size: meta.ContentLength, LastModified: modified}, nil
+	}
+	if !isNotFound(err) {
+		return nil, fmt.Errorf("failed to stat %s: %w", p, err)
+	}
+
+	err = w.Files.GetDirectoryMetadata(ctx, files.GetDirectoryMetadataRequest{DirectoryPath: p})
+	if err == nil {
+		return &Entry{Path: p, Name: path.Base(p), IsDirectory: true}, nil
+	}
+	if isNotFound(err) {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("failed to stat %s: %w", p, err)
+}
+
+// Upload streams r to a file in a volume, creating parent directories as needed.
+func Upload(ctx context.Context, w *databricks.WorkspaceClient, p string, r io.Reader, overwrite bool) error {
+	err := w.Files.Upload(ctx, files.UploadRequest{
+		FilePath:  p,
+		Contents:  io.NopCloser(r),
+		Overwrite: overwrite,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", p, err)
+	}
+	return nil
+}
+
+// rangeResponse is a Files API download whose body is left unread.
+type rangeResponse struct {
+	ContentRange string        `json:"-" url:"-" header:"content-range,omitempty"`
+	Contents     io.ReadCloser `json:"-"`
+}
+
+// Download opens a file for reading starting at offset. A length of zero reads
+// to the end of the file. The Files API SDK call has no range support, so the
+// request is made through a client built from the workspace configuration,
+// which keeps its authentication, retries and timeouts, with a Range header.
+func Download(ctx context.Context, w *databricks.WorkspaceClient, p string, offset, length int64) (io.ReadCloser, error) {
+	c, err := client.New(w.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{"Accept": "application/octet-stream"}
+	switch {
+	case length > 0:
+		headers["Range"] = fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	case offset > 0:
+		headers["Range"] = fmt.Sprintf("bytes=%d-", offset)
+	}
+
+	var resp rangeResponse
+	err = c.Do(ctx, http.MethodGet, "/api/2.0/fs/files"+escapePath(p), headers, nil, nil, &resp)
+	var apiErr *apierr.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", p, err)
+	}
+	if resp.ContentRange != "" || headers["Range"] == "" {
+		return resp.Contents, nil
+	}
+
+	// The server ignored the range: skip to the offset ourselves.
+	if offset > 0 {
+		if _, err := io.CopyN(io.Discard, resp.Contents, offset); err != nil {
+			resp.Contents.Close()
+			return nil, fmt.Errorf("failed to seek %s: %w", p, err)
+		}
+	}
+	if length > 0 {
+		return readCloser{io.LimitReader(resp.Contents, length), resp.Contents}, nil
+	}
+	return resp.Contents, nil
+}
+
+// Delete removes a file, or an empty directory.
+func Delete(ctx context.Context, w *databricks.WorkspaceClient, p string, isDirectory bool) error {
+	var err error
+	if isDirectory {
+		err = w.Files.DeleteDirectory(ctx, files.DeleteDirectoryRequest{DirectoryPath: p})
+	} else {
+		err = w.Files.Delete(ctx, files.DeleteFileRequest{FilePath: p})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", p, err)
+	}
+	return nil
+}
+
+// DeleteRecursive removes a directory and everything below it.
+func DeleteRecursive(ctx context.Context, w *databricks.WorkspaceClient, dir string) error {
+	entries, err := List(ctx, w, dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDirectory {
+			err = DeleteRecursive(ctx, w, e.Path)
+		} else {
+			err = Delete(ctx, w, e.Path, false)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return Delete(ctx, w, dir, true)
+}
+
+func isNotFound(err error) bool {
+	var apiErr *apierr.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusNotFound
+	}
+	return errors.Is(err, apierr.ErrNotFound)
+}
+
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
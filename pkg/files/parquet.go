@@ -0,0 +1,115 @@
+package files
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow/go/v12/parquet/metadata"
+	"github.com/databricks/databricks-sdk-go"
+)
+
+const parquetMagic = "PAR1"
+
+// previewParquet reads only the Parquet footer with two ranged requests and
+// renders the file summary, the schema and the first row group's column chunks.
+func previewParquet(ctx context.Context, w *databricks.WorkspaceClient, e Entry) ([]Table, error) {
+	if e.Size < 12 {
+		return nil, fmt.Errorf("%s is too small to be a Parquet file", e.Path)
+	}
+
+	tail, err := readRange(ctx, w, e.Path, e.Size-8, 8)
+	if err != nil {
+		return nil, err
+	}
+	if string(tail[4:]) != parquetMagic {
+		return nil, fmt.Errorf("%s is not a Parquet file (missing footer magic)", e.Path)
+	}
+	footerLen := int64(binary.LittleEndian.Uint32(tail[:4]))
+	if footerLen <= 0 || footerLen > e.Size-12 {
+		return nil, fmt.Errorf("%s has an invalid footer length %d", e.Path, footerLen)
+	}
+
+	footer, err := readRange(ctx, w, e.Path, e.Size-8-footerLen, footerLen)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := metadata.NewFileMetaData(footer, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Parquet footer: %w", err)
+	}
+
+	summary := Table{
+		Title:   "File",
+		Headers: []string{"Property", "Value"},
+		Rows: [][]string{
+			{"Rows", fmt.Sprintf("%d", meta.GetNumRows())},
+			{"Row Groups", fmt.Sprintf("%d", len(meta.GetRowGroups()))},
+			{"Columns", fmt.Sprintf("%d", meta.Schema.NumColumns())},
+			{"Created By", meta.GetCreatedBy()},
+			{"Footer Size", fmt.Sprintf("%d bytes", footerLen)},
+		},
+	}
+
+	schemaTable := Table{Title: "Schema", Headers: []string{"Column", "Physical Type", "Logical Type", "Max Def/Rep"}}
+	for i := 0; i < meta.Schema.NumColumns(); i++ {
+		col := meta.Schema.Column(i)
+		logical := "-"
+		if lt := col.LogicalType(); lt != nil && !lt.IsNone() {
+			logical = lt.String()
+		}
+		schemaTable.Rows = append(schemaTable.Rows, []string{
+			col.Path(), col.PhysicalType().String(), logical,
+			fmt.Sprintf("%d/%d", col.MaxDefinitionLevel(), col.MaxRepetitionLevel()),
+		})
+	}
+
+	tables := []Table{summary, schemaTable}
+	if len(meta.GetRowGroups()) == 0 {
+		return tables, nil
+	}
+
+	rg := meta.RowGroup(0)
+	rgTable := Table{
+		Title:   fmt.Sprintf("Row Group 0 (%d rows, %d bytes)", rg.NumRows(), rg.TotalByteSize()),
+		Headers: []string{"Column", "Values", "Nulls", "Min", "Max", "Compression", "Compressed"},
+	}
+	for i := 0; i < rg.NumColumns(); i++ {
+		cc, err := rg.ColumnChunk(i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read column chunk %d: %w", i, err)
+		}
+		nulls, min, max := "-", "-", "-"
+		if ok, _ := cc.StatsSet(); ok {
+			if stats, err := cc.Statistics(); err == nil && stats != nil {
+				if stats.HasNullCount() {
+					nulls = fmt.Sprintf("%d", stats.NullCount())
+				}
+				if stats.HasMinMax() {
+					min = clip(fmt.Sprintf("%v", metadata.GetStatValue(cc.Type(), stats.EncodeMin())))
+					max = clip(fmt.Sprintf("%v", metadata.GetStatValue(cc.Type(), stats.EncodeMax())))
+				}
+			}
+		}
+		rgTable.Rows = append(rgTable.Rows, []string{
+			cc.PathInSchema().String(), fmt.Sprintf("%d", cc.NumValues()), nulls, min, max,
+			cc.Compression().String(), fmt.Sprintf("%d", cc.TotalCompressedSize()),
+		})
+	}
+	return append(tables, rgTable), nil
+}
+
+func readRange(ctx context.Context, w *databricks.WorkspaceClient, p string, offset, length int64) ([]byte, error) {
+	body, err := Download(ctx, w, p, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(body, buf); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", p, err)
+	}
+	return buf, nil
+}
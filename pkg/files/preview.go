@@ -0,0 +1,311 @@
+package files
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"dbx-explore/pkg/ui"
+
+	"github.com/databricks/databricks-sdk-go"
+)
+
+// Format is the renderer used to preview a file.
+type Format string
+
+const (
+	FormatCSV       Format = "csv"
+	FormatTSV       Format = "tsv"
+	FormatJSON      Format = "json"
+	FormatJSONLines Format = "jsonl"
+	FormatParquet   Format = "parquet"
+	FormatText      Format = "text"
+)
+
+// maxLineWidth is the widest line shown in a text preview.
+const maxLineWidth = 200
+
+// Table is one titled table of a preview.
+type Table struct {
+	Title   string
+	Headers []string
+	Rows    [][]string
+}
+
+// DetectFormat picks a preview format from the file extension.
+func DetectFormat(p string) Format {
+	switch strings.ToLower(path.Ext(p)) {
+	case ".csv":
+		return FormatCSV
+	case ".tsv", ".tab":
+		return FormatTSV
+	case ".json":
+		return FormatJSON
+	case ".jsonl", ".ndjson":
+		return FormatJSONLines
+	case ".parquet":
+		return FormatParquet
+	default:
+		return FormatText
+	}
+}
+
+// Preview renders the beginning of a volume file without downloading all of
+// it. Only the first maxBytes are fetched (Parquet files read their footer
+// instead) and at most maxRows rows or lines are returned.
+func Preview(ctx context.Context, w *databricks.WorkspaceClient, e Entry, maxBytes int64, maxRows int) ([]Table, error) {
+	format := DetectFormat(e.Path)
+	if format == FormatParquet {
+		return previewParquet(ctx, w, e)
+	}
+
+	length := maxBytes
+	if e.Size > 0 && e.Size < length {
+		length = e.Size
+	}
+	body, err := Download(ctx, w, e.Path, 0, length)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", e.Path, err)
+	}
+	truncated := int64(len(data)) < e.Size
+
+	switch format {
+	case FormatCSV:
+		return previewDelimited(data, ',', truncated, maxRows)
+	case FormatTSV:
+		return previewDelimited(data, '\t', truncated, maxRows)
+	case FormatJSON, FormatJSONLines:
+		return previewJSON(data, truncated, maxRows)
+	default:
+		return previewText(data, truncated, maxRows)
+	}
+}
+
+// completeLines drops a trailing partial line from a truncated read.
+func completeLines(data []byte, truncated bool) []byte {
+	if !truncated {
+		return data
+	}
+	if i := bytes.LastIndexByte(data, '\n'); i >= 0 {
+		return data[:i+1]
+	}
+	return data
+}
+
+func previewDelimited(data []byte, comma rune, truncated bool, maxRows int) ([]Table, error) {
+	r := csv.NewReader(bytes.NewReader(completeLines(data, truncated)))
+	r.Comma = comma
+	r.LazyQuotes = true
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse header: %w", err)
+	}
+
+	var rows [][]string
+	for len(rows) < maxRows {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse row %d: %w", len(rows)+1, err)
+		}
+		rows = append(rows, clipAll(rec))
+	}
+	return []Table{{Headers: clipAll(header), Rows: rows}}, nil
+}
+
+// previewJSON handles JSON lines, a top-level JSON array of objects and a
+// single (pretty-printed) JSON document. Truncated input is decoded up to the
+// last complete record.
+func previewJSON(data []byte, truncated bool, maxRows int) ([]Table, error) {
+	var records []map[string]interface{}
+	var order []string
+	seen := make(map[string]bool)
+	add := func(rec map[string]interface{}, keys []string) {
+		for _, k := range keys {
+			if !seen[k] {
+				seen[k] = true
+				order = append(order, k)
+			}
+		}
+		records = append(records, rec)
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		if _, err := dec.Token(); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		for dec.More() && len(records) < maxRows {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				if truncated {
+					break
+				}
+				return nil, fmt.Errorf("failed to parse JSON: %w", err)
+			}
+			rec, keys, err := decodeObject(raw)
+			if err != nil {
+				return nil, err
+			}
+			add(rec, keys)
+		}
+	} else {
+		lines, err := jsonLines(completeLines(data, truncated), maxRows)
+		if err != nil {
+			// Not JSON lines: try the input as one document spread over
+			// several lines.
+			rec, keys, docErr := decodeObject(trimmed)
+			if docErr != nil {
+				if truncated {
+					return nil, fmt.Errorf("JSON document is too large to preview: %w", err)
+				}
+				return nil, err
+			}
+			lines = []jsonRecord{{rec, keys}}
+		}
+		for _, l := range lines {
+			add(l.values, l.keys)
+		}
+	}
+
+	rows := make([][]string, len(records))
+	for i, rec := range records {
+		row := make([]string, len(order))
+		for j, k := range order {
+			if v, ok := rec[k]; ok {
+				row[j] = clip(formatJSONValue(v))
+			}
+		}
+		rows[i] = row
+	}
+	return []Table{{Headers: order, Rows: rows}}, nil
+}
+
+type jsonRecord struct {
+	values map[string]interface{}
+	keys   []string
+}
+
+// jsonLines decodes up to maxRows records, one JSON value per line.
+func jsonLines(data []byte, maxRows int) ([]jsonRecord, error) {
+	var records []jsonRecord
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if len(records) >= maxRows {
+			break
+		}
+		rec, keys, err := decodeObject(line)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JSON line %d: %w", len(records)+1, err)
+		}
+		records = append(records, jsonRecord{rec, keys})
+	}
+	return records, nil
+}
+
+// decodeObject decodes a JSON object and returns its keys in document order.
+func decodeObject(raw []byte) (map[string]interface{}, []string, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		var v interface{}
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, nil, err
+		}
+		return map[string]interface{}{"value": v}, []string{"value"}, nil
+	}
+
+	rec := make(map[string]interface{})
+	var keys []string
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key := t.(string)
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return nil, nil, err
+		}
+		rec[key] = v
+		keys = append(keys, key)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, err
+	}
+	if dec.More() {
+		return nil, nil, errors.New("unexpected data after JSON object")
+	}
+	return rec, keys, nil
+}
+
+func formatJSONValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return val
+	case map[string]interface{}, []interface{}:
+		b, _ := json.Marshal(val)
+		return string(b)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+func previewText(data []byte, truncated bool, maxRows int) ([]Table, error) {
+	sniff := data
+	if len(sniff) > 8000 {
+		sniff = sniff[:8000]
+	}
+	// A truncated read may end in the middle of a multi-byte character.
+	for i := 0; i < utf8.UTFMax && len(sniff) > 0 && !utf8.Valid(sniff); i++ {
+		sniff = sniff[:len(sniff)-1]
+	}
+	if bytes.IndexByte(sniff, 0) >= 0 || !utf8.Valid(sniff) {
+		return nil, fmt.Errorf("binary file; no preview available")
+	}
+
+	text := strings.TrimSuffix(string(completeLines(data, truncated)), "\n")
+	var rows [][]string
+	for i, line := range strings.Split(text, "\n") {
+		if i >= maxRows {
+			break
+		}
+		rows = append(rows, []string{fmt.Sprintf("%d", i+1), ui.Truncate(strings.TrimRight(line, "\r"), maxLineWidth)})
+	}
+	return []Table{{Headers: []string{"Line", "Text"}, Rows: rows}}, nil
+}
+
+func clip(s string) string {
+	return ui.Truncate(strings.ReplaceAll(s, "\n", "⏎"), ui.MaxCellWidth)
+}
+
+func clipAll(cells []string) []string {
+	out := make([]string, len(cells))
+	for i, c := range cells {
+		out[i] = clip(c)
+	}
+	return out
+}
@@ -0,0 +1,107 @@
+package files
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPreviewDelimited(t *testing.T) {
+	data := []byte("id,name\n1,alice\n2,\"bob, jr\"\n3,carol\n4,da")
+	tables, err := previewDelimited(data, ',', true, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Table{
+		Headers: []string{"id", "name"},
+		Rows:    [][]string{{"1", "alice"}, {"2", "bob, jr"}, {"3", "carol"}},
+	}
+	if !reflect.DeepEqual(tables, []Table{want}) {
+		t.Errorf("truncated csv = %+v", tables)
+	}
+
+	tables, err = previewDelimited([]byte("a\tb\nx\ty\nz\tw\n"), '\t', false, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := tables[0].Rows; !reflect.DeepEqual(got, [][]string{{"x", "y"}}) {
+		t.Errorf("tsv rows = %v", got)
+	}
+}
+
+func TestPreviewJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		truncated bool
+		headers   []string
+		rows      [][]string
+	}{
+		{
+			name:    "lines",
+			data:    "{\"id\":1,\"name\":\"a\"}\n{\"id\":2,\"tags\":[\"x\"]}\n",
+			headers: []string{"id", "name", "tags"},
+			rows:    [][]string{{"1", "a", ""}, {"2", "", `["x"]`}},
+		},
+		{
+			name:      "truncated lines",
+			data:      "{\"id\":1}\n{\"id\":2}\n{\"id\":",
+			truncated: true,
+			headers:   []string{"id"},
+			rows:      [][]string{{"1"}, {"2"}},
+		},
+		{
+			name:    "array",
+			data:    "[\n  {\"id\": 1},\n  {\"id\": 2, \"ok\": true}\n]",
+			headers: []string{"id", "ok"},
+			rows:    [][]string{{"1", ""}, {"2", "true"}},
+		},
+		{
+			name:    "pretty-printed document",
+			data:    "{\n  \"name\": \"orders\",\n  \"owner\": null,\n  \"columns\": [\"id\", \"total\"]\n}\n",
+			headers: []string{"name", "owner", "columns"},
+			rows:    [][]string{{"orders", "null", `["id","total"]`}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tables, err := previewJSON([]byte(tt.data), tt.truncated, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tables[0].Headers, tt.headers) || !reflect.DeepEqual(tables[0].Rows, tt.rows) {
+				t.Errorf("previewJSON = %v %v, want %v %v", tables[0].Headers, tables[0].Rows, tt.headers, tt.rows)
+			}
+		})
+	}
+
+	if _, err := previewJSON([]byte("{\"id\": 1}\nnot json\n"), false, 10); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+	if _, err := previewJSON([]byte("{\n  \"id\": 1,\n  \"name\": "), true, 10); err == nil {
+		t.Error("expected an error for a truncated document")
+	}
+}
+
+func TestPreviewText(t *testing.T) {
+	long := strings.Repeat("x", maxLineWidth+50)
+	tables, err := previewText([]byte("first\r\n"+long+"\nthird\nfour"), true, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := tables[0].Rows
+	if len(rows) != 3 || rows[0][1] != "first" || rows[2][1] != "third" {
+		t.Fatalf("rows = %v", rows)
+	}
+	if n := len([]rune(rows[1][1])); n != maxLineWidth || !strings.HasSuffix(rows[1][1], "…") {
+		t.Errorf("long line not clipped: %d runes", n)
+	}
+
+	if _, err := previewText([]byte("PK\x03\x04\x00\x00binary"), false, 10); err == nil {
+		t.Error("expected an error for binary data")
+	}
+	// A multi-byte character cut by the read limit is still text.
+	if _, err := previewText([]byte("héllo\nwörld\xc3"), true, 10); err != nil {
+		t.Errorf("truncated UTF-8: %v", err)
+	}
+}
@@ -0,0 +1,364 @@
+package files
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go"
+)
+
+// SyncDirection says which side of a sync is the source.
+type SyncDirection string
+
+const (
+	SyncUp   SyncDirection = "up"   // local directory -> volume
+	SyncDown SyncDirection = "down" // volume -> local directory
+)
+
+// SyncAction is what a sync does with one file.
+type SyncAction string
+
+const (
+	SyncUpload   SyncAction = "upload"
+	SyncDownload SyncAction = "download"
+	SyncDelete   SyncAction = "delete"
+)
+
+// SyncOp is one planned or executed file operation. Path is relative to the
+// synced directories and always uses forward slashes.
+type SyncOp struct {
+	Action SyncAction `json:"action"`
+	Path   string     `json:"path"`
+	Size   int64      `json:"size"`
+	Reason string     `json:"reason"`
+	Error  string     `json:"error,omitempty"`
+
+	modTime time.Time
+}
+
+// SyncOptions controls how a sync compares and transfers files.
+type SyncOptions struct {
+	// Delete removes destination files that no longer exist at the source.
+	Delete bool
+	// DryRun plans the operations without executing them.
+	DryRun bool
+	// Checksum compares SHA-256 hashes of same-sized files instead of
+	// modification times. Remote files are downloaded to hash them.
+	Checksum bool
+	// Concurrency is the number of parallel transfers (default 4).
+	Concurrency int
+	// OnComplete is called after each operation, possibly from several
+	// goroutines at once.
+	OnComplete func(op SyncOp, err error)
+}
+
+// SyncSummary describes the outcome of a sync.
+type SyncSummary struct {
+	Direction   SyncDirection `json:"direction"`
+	Local       string        `json:"local"`
+	Remote      string        `json:"remote"`
+	DryRun      bool          `json:"dry_run"`
+	Unchanged   int           `json:"unchanged"`
+	Operations  []SyncOp      `json:"operations"`
+	StartedAt   time.Time     `json:"started_at"`
+	CompletedAt time.Time     `json:"completed_at"`
+}
+
+// Count returns the number of successful operations of the given action.
+func (s *SyncSummary) Count(action SyncAction) int {
+	n := 0
+	for _, op := range s.Operations {
+		if op.Action == action && op.Error == "" {
+			n++
+		}
+	}
+	return n
+}
+
+// Bytes returns the number of bytes transferred by successful operations.
+func (s *SyncSummary) Bytes() int64 {
+	var n int64
+	for _, op := range s.Operations {
+		if op.Action != SyncDelete && op.Error == "" {
+			n += op.Size
+		}
+	}
+	return n
+}
+
+// Failed returns the number of operations that returned an error.
+func (s *SyncSummary) Failed() int {
+	n := 0
+	for _, op := range s.Operations {
+		if op.Error != "" {
+			n++
+		}
+	}
+	return n
+}
+
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// Sync mirrors a local directory to a volume directory or the other way round.
+// Only files that are new or changed at the source are transferred; with
+// Delete set, files missing at the source are removed from the destination.
+// Per-file failures are recorded in the summary rather than returned.
+func Sync(ctx context.Context, w *databricks.WorkspaceClient, local, remote string, dir SyncDirection, opts SyncOptions) (*SyncSummary, error) {
+	summary := &SyncSummary{Direction: dir, Local: local, Remote: remote, DryRun: opts.DryRun, StartedAt: time.Now()}
+
+	ops, unchanged, err := PlanSync(ctx, w, local, remote, dir, opts)
+	if err != nil {
+		return nil, err
+	}
+	summary.Unchanged = unchanged
+	summary.Operations = ops
+
+	if !opts.DryRun {
+		parallel(opts.Concurrency, len(ops), func(i int) {
+			err := runSyncOp(ctx, w, local, remote, dir, ops[i])
+			if err != nil {
+				ops[i].Error = err.Error()
+			}
+			if opts.OnComplete != nil {
+				opts.OnComplete(ops[i], err)
+			}
+		})
+	}
+
+	summary.CompletedAt = time.Now()
+	return summary, nil
+}
+
+// PlanSync compares both sides and returns the operations a sync would run
+// along with the number of files that are already up to date.
+func PlanSync(ctx context.Context, w *databricks.WorkspaceClient, local, remote string, dir SyncDirection, opts SyncOptions) ([]SyncOp, int, error) {
+	localFiles, err := listLocal(local, dir == SyncUp)
+	if err != nil {
+		return nil, 0, err
+	}
+	remoteFiles, err := listRemote(ctx, w, remote, dir == SyncDown)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	src, dst, action := localFiles, remoteFiles, SyncUpload
+	if dir == SyncDown {
+		src, dst, action = remoteFiles, localFiles, SyncDownload
+	}
+
+	var ops, candidates []SyncOp
+	for _, rel := range sortedPaths(src) {
+		s := src[rel]
+		d, ok := dst[rel]
+		switch {
+		case !ok:
+			ops = append(ops, SyncOp{Action: action, Path: rel, Size: s.size, Reason: "new", modTime: s.modTime})
+		case s.size != d.size:
+			ops = append(ops, SyncOp{Action: action, Path: rel, Size: s.size, Reason: "size changed", modTime: s.modTime})
+		case opts.Checksum:
+			candidates = append(candidates, SyncOp{Action: action, Path: rel, Size: s.size, Reason: "content changed", modTime: s.modTime})
+		case s.modTime.Truncate(time.Second).After(d.modTime.Truncate(time.Second)):
+			ops = append(ops, SyncOp{Action: action, Path: rel, Size: s.size, Reason: "newer", modTime: s.modTime})
+		}
+	}
+	unchanged := len(src) - len(ops) - len(candidates)
+
+	// Hashing needs the remote contents, so spread it over the worker pool.
+	changed := make([]bool, len(candidates))
+	errs := make([]error, len(candidates))
+	parallel(opts.Concurrency, len(candidates), func(i int) {
+		rel := candidates[i].Path
+		localSum, err := localChecksum(filepath.Join(local, filepath.FromSlash(rel)))
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		remoteSum, err := remoteChecksum(ctx, w, path.Join(remote, rel))
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		changed[i] = localSum != remoteSum
+	})
+	for i, op := range candidates {
+		if errs[i] != nil {
+			return nil, 0, errs[i]
+		}
+		if changed[i] {
+			ops = append(ops, op)
+		} else {
+			unchanged++
+		}
+	}
+
+	if opts.Delete {
+		for _, rel := range sortedPaths(dst) {
+			if _, ok := src[rel]; !ok {
+				ops = append(ops, SyncOp{Action: SyncDelete, Path: rel, Size: dst[rel].size, Reason: "missing at source"})
+			}
+		}
+	}
+	return ops, unchanged, nil
+}
+
+func runSyncOp(ctx context.Context, w *databricks.WorkspaceClient, local, remote string, dir SyncDirection, op SyncOp) error {
+	localPath := filepath.Join(local, filepath.FromSlash(op.Path))
+	remotePath := path.Join(remote, op.Path)
+
+	switch op.Action {
+	case SyncUpload:
+		return UploadFile(ctx, w, localPath, remotePath, true, nil)
+	case SyncDownload:
+		if err := DownloadFile(ctx, w, remotePath, localPath, false, nil); err != nil {
+			return err
+		}
+		// Keep the remote timestamp so the next sync sees the file as current.
+		if op.modTime.IsZero() {
+			return nil
+		}
+		return os.Chtimes(localPath, op.modTime, op.modTime)
+	case SyncDelete:
+		if dir == SyncUp {
+			return Delete(ctx, w, remotePath, false)
+		}
+		if err := os.Remove(localPath); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", localPath, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown sync action %q", op.Action)
+}
+
+// listLocal returns the files below dir keyed by slash-separated relative path.
+// A missing directory is an error only when it is the sync source.
+func listLocal(dir string, mustExist bool) (map[string]fileState, error) {
+	out := make(map[string]fileState)
+	st, err := os.Stat(dir)
+	if os.IsNotExist(err) && !mustExist {
+		return out, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", dir, err)
+	}
+	if !st.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	err = filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		out[filepath.ToSlash(rel)] = fileState{size: fi.Size(), modTime: fi.ModTime()}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", dir, err)
+	}
+	return out, nil
+}
+
+// listRemote returns the files below a volume directory keyed by relative path.
+func listRemote(ctx context.Context, w *databricks.WorkspaceClient, dir string, mustExist bool) (map[string]fileState, error) {
+	out := make(map[string]fileState)
+	info, err := Stat(ctx, w, dir)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		if mustExist {
+			return nil, fmt.Errorf("%s does not exist", dir)
+		}
+		return out, nil
+	}
+	if !info.IsDirectory {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	entries, err := Walk(ctx, w, dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		rel := strings.TrimPrefix(strings.TrimPrefix(e.Path, dir), "/")
+		out[rel] = fileState{size: e.Size, modTime: e.LastModified}
+	}
+	return out, nil
+}
+
+func sortedPaths(m map[string]fileState) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func localChecksum(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", p, err)
+	}
+	defer f.Close()
+	return checksum(f)
+}
+
+func remoteChecksum(ctx context.Context, w *databricks.WorkspaceClient, p string) (string, error) {
+	body, err := Download(ctx, w, p, 0, 0)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+	sum, err := checksum(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", p, err)
+	}
+	return sum, nil
+}
+
+func checksum(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// parallel calls fn for 0..n-1 on at most concurrency goroutines.
+func parallel(concurrency, n int, fn func(i int)) {
+	if concurrency < 1 {
+		concurrency = 4
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency && i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				fn(j)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,345 @@
+package files
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/files"
+)
+
+// fakeFilesAPI is an in-memory implementation of the Files API endpoints used
+// by this package.
+type fakeFilesAPI struct {
+	mu    sync.Mutex
+	files map[string]fakeFile
+	puts  int
+	// getError, when set, fails every file download with this status.
+	getError int
+}
+
+type fakeFile struct {
+	data     []byte
+	modified time.Time
+}
+
+func newFakeFilesAPI(t *testing.T) (*fakeFilesAPI, *databricks.WorkspaceClient) {
+	t.Helper()
+	api := &fakeFilesAPI{files: make(map[string]fakeFile)}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	w, err := databricks.NewWorkspaceClient(&databricks.Config{Host: srv.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return api, w
+}
+
+func (f *fakeFilesAPI) put(p, data string, modified time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.files[p] = fakeFile{data: []byte(data), modified: modified}
+}
+
+func (f *fakeFilesAPI) get(p string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, ok := f.files[p]
+	return string(file.data), ok
+}
+
+func (f *fakeFilesAPI) isDir(p string) bool {
+	for name := range f.files {
+		if strings.HasPrefix(name, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeFilesAPI) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/api/2.0/fs/files/"):
+		f.serveFile(rw, r, strings.TrimPrefix(r.URL.Path, "/api/2.0/fs/files"))
+	case strings.HasPrefix(r.URL.Path, "/api/2.0/fs/directories/"):
+		f.serveDirectory(rw, r, strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/2.0/fs/directories"), "/"))
+	default:
+		notFound(rw)
+	}
+}
+
+func (f *fakeFilesAPI) serveFile(rw http.ResponseWriter, r *http.Request, p string) {
+	file, ok := f.files[p]
+	switch r.Method {
+	case http.MethodHead:
+		if !ok {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rw.Header().Set("Content-Length", fmt.Sprint(len(file.data)))
+		rw.Header().Set("Last-Modified", file.modified.UTC().Format(http.TimeFormat))
+	case http.MethodGet:
+		if f.getError != 0 {
+			rw.WriteHeader(f.getError)
+			fmt.Fprint(rw, `{"error_code":"PERMISSION_DENIED","message":"denied"}`)
+			return
+		}
+		if !ok {
+			notFound(rw)
+			return
+		}
+		var start, end int
+		if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &start, &end); err == nil {
+			rw.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(file.data)))
+			rw.WriteHeader(http.StatusPartialContent)
+			rw.Write(file.data[start : end+1])
+			return
+		}
+		rw.Write(file.data)
+	case http.MethodPut:
+		if ok && r.URL.Query().Get("overwrite") != "true" {
+			rw.WriteHeader(http.StatusConflict)
+			fmt.Fprint(rw, `{"error_code":"ALREADY_EXISTS","message":"file exists"}`)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		f.files[p] = fakeFile{data: data, modified: time.Now()}
+		f.puts++
+	case http.MethodDelete:
+		if !ok {
+			notFound(rw)
+			return
+		}
+		delete(f.files, p)
+	}
+}
+
+func (f *fakeFilesAPI) serveDirectory(rw http.ResponseWriter, r *http.Request, dir string) {
+	if !f.isDir(dir) {
+		if r.Method == http.MethodHead {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		notFound(rw)
+		return
+	}
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	seen := make(map[string]bool)
+	var resp files.ListDirectoryResponse
+	for name, file := range f.files {
+		rel := strings.TrimPrefix(name, dir+"/")
+		if rel == name {
+			continue
+		}
+		child, _, isDir := strings.Cut(rel, "/")
+		if seen[child] {
+			continue
+		}
+		seen[child] = true
+		e := files.DirectoryEntry{Name: child, Path: path.Join(dir, child), IsDirectory: isDir}
+		if !isDir {
+			e.FileSize = int64(len(file.data))
+			e.LastModified = file.modified.UnixMilli()
+		}
+		resp.Contents = append(resp.Contents, e)
+	}
+	sort.Slice(resp.Contents, func(i, j int) bool { return resp.Contents[i].Name < resp.Contents[j].Name })
+	json.NewEncoder(rw).Encode(resp)
+}
+
+func notFound(rw http.ResponseWriter) {
+	rw.WriteHeader(http.StatusNotFound)
+	fmt.Fprint(rw, `{"error_code":"NOT_FOUND","message":"not found"}`)
+}
+
+func writeLocal(t *testing.T, dir, rel, data string, modified time.Time) {
+	t.Helper()
+	p := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(p, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func opsByPath(ops []SyncOp) map[string]SyncOp {
+	out := make(map[string]SyncOp)
+	for _, op := range ops {
+		out[op.Path] = op
+	}
+	return out
+}
+
+const testVolume = "/Volumes/main/ml/artifacts/data"
+
+func TestSyncUp(t *testing.T) {
+	api, w := newFakeFilesAPI(t)
+	local := t.TempDir()
+	past := time.Now().Add(-time.Hour)
+	older := time.Now().Add(-2 * time.Hour)
+
+	writeLocal(t, local, "new.csv", "a,b\n1,2\n", past)
+	writeLocal(t, local, "nested/same.txt", "same", past)
+	writeLocal(t, local, "nested/resized.txt", "longer content", past)
+	writeLocal(t, local, "touched.txt", "abcd", past)
+	api.put(testVolume+"/nested/same.txt", "same", time.Now())
+	api.put(testVolume+"/nested/resized.txt", "short", time.Now())
+	api.put(testVolume+"/touched.txt", "abcd", older)
+	api.put(testVolume+"/stale.bin", "old", time.Now())
+
+	ctx := context.Background()
+	dry, err := Sync(ctx, w, local, testVolume, SyncUp, SyncOptions{Delete: true, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]SyncOp{
+		"new.csv":            {Action: SyncUpload, Reason: "new"},
+		"nested/resized.txt": {Action: SyncUpload, Reason: "size changed"},
+		"touched.txt":        {Action: SyncUpload, Reason: "newer"},
+		"stale.bin":          {Action: SyncDelete, Reason: "missing at source"},
+	}
+	got := opsByPath(dry.Operations)
+	if len(got) != len(want) {
+		t.Fatalf("planned %d operations, want %d: %+v", len(got), len(want), dry.Operations)
+	}
+	for p, w := range want {
+		if got[p].Action != w.Action || got[p].Reason != w.Reason {
+			t.Errorf("%s: got %s (%s), want %s (%s)", p, got[p].Action, got[p].Reason, w.Action, w.Reason)
+		}
+	}
+	if dry.Unchanged != 1 {
+		t.Errorf("unchanged = %d, want 1", dry.Unchanged)
+	}
+	if api.puts != 0 {
+		t.Fatalf("dry run uploaded %d files", api.puts)
+	}
+
+	summary, err := Sync(ctx, w, local, testVolume, SyncUp, SyncOptions{Delete: true, Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Failed() != 0 {
+		t.Fatalf("failed operations: %+v", summary.Operations)
+	}
+	if summary.Count(SyncUpload) != 3 || summary.Count(SyncDelete) != 1 {
+		t.Errorf("uploaded %d, deleted %d; want 3 and 1", summary.Count(SyncUpload), summary.Count(SyncDelete))
+	}
+	if data, _ := api.get(testVolume + "/nested/resized.txt"); data != "longer content" {
+		t.Errorf("resized.txt = %q", data)
+	}
+	if _, ok := api.get(testVolume + "/stale.bin"); ok {
+		t.Error("stale.bin was not deleted")
+	}
+
+	again, err := Sync(ctx, w, local, testVolume, SyncUp, SyncOptions{Delete: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Operations) != 0 || again.Unchanged != 4 {
+		t.Errorf("second sync: %d operations, %d unchanged; want 0 and 4", len(again.Operations), again.Unchanged)
+	}
+}
+
+func TestSyncDown(t *testing.T) {
+	api, w := newFakeFilesAPI(t)
+	local := t.TempDir()
+	modified := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	api.put(testVolume+"/model/weights.bin", "0123456789", modified)
+	api.put(testVolume+"/model/config.json", `{"layers":2}`, modified)
+	writeLocal(t, local, "extra.txt", "local only", modified)
+
+	ctx := context.Background()
+	summary, err := Sync(ctx, w, local, testVolume, SyncDown, SyncOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Count(SyncDownload) != 2 || summary.Failed() != 0 {
+		t.Fatalf("unexpected summary: %+v", summary.Operations)
+	}
+	if summary.Bytes() != 22 {
+		t.Errorf("bytes = %d, want 22", summary.Bytes())
+	}
+	data, err := os.ReadFile(filepath.Join(local, "model", "weights.bin"))
+	if err != nil || string(data) != "0123456789" {
+		t.Fatalf("weights.bin = %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(local, "extra.txt")); err != nil {
+		t.Error("extra.txt was removed without --delete")
+	}
+
+	// Downloaded files keep the remote timestamp, so nothing changes on a rerun.
+	again, err := Sync(ctx, w, local, testVolume, SyncDown, SyncOptions{Delete: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Count(SyncDownload) != 0 || again.Count(SyncDelete) != 1 || again.Unchanged != 2 {
+		t.Errorf("second sync: %+v (unchanged %d)", again.Operations, again.Unchanged)
+	}
+	if _, err := os.Stat(filepath.Join(local, "extra.txt")); !os.IsNotExist(err) {
+		t.Error("extra.txt was not deleted")
+	}
+}
+
+func TestSyncChecksum(t *testing.T) {
+	api, w := newFakeFilesAPI(t)
+	local := t.TempDir()
+	past := time.Now().Add(-time.Hour)
+
+	writeLocal(t, local, "same.txt", "aaaa", past)
+	writeLocal(t, local, "edited.txt", "bbbb", past)
+	api.put(testVolume+"/same.txt", "aaaa", time.Now())
+	api.put(testVolume+"/edited.txt", "cccc", time.Now())
+
+	ctx := context.Background()
+	ops, unchanged, err := PlanSync(ctx, w, local, testVolume, SyncUp, SyncOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 0 || unchanged != 2 {
+		t.Fatalf("without checksums: %+v (unchanged %d)", ops, unchanged)
+	}
+
+	ops, unchanged, err = PlanSync(ctx, w, local, testVolume, SyncUp, SyncOptions{Checksum: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 1 || ops[0].Path != "edited.txt" || ops[0].Reason != "content changed" || unchanged != 1 {
+		t.Errorf("with checksums: %+v (unchanged %d)", ops, unchanged)
+	}
+}
+
+func TestSyncMissingSource(t *testing.T) {
+	_, w := newFakeFilesAPI(t)
+	ctx := context.Background()
+
+	if _, err := Sync(ctx, w, filepath.Join(t.TempDir(), "missing"), testVolume, SyncUp, SyncOptions{}); err == nil {
+		t.Error("expected an error for a missing local source")
+	}
+	if _, err := Sync(ctx, w, t.TempDir(), testVolume, SyncDown, SyncOptions{}); err == nil {
+		t.Error("expected an error for a missing remote source")
+	}
+}
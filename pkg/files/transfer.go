@@ -0,0 +1,127 @@
+package files
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+)
+
+// ProgressFunc creates a writer that receives a copy of every transferred byte.
+// total is the full size and start the number of bytes already transferred.
+// It may return nil to disable progress reporting.
+type ProgressFunc func(total, start int64) io.Writer
+
+// NormalizePath strips a "dbfs:" scheme so both dbfs:/Volumes/... and
+// /Volumes/... forms are accepted.
+func NormalizePath(p string) string {
+	return strings.TrimPrefix(p, "dbfs:")
+}
+
+// IsVolumePath reports whether p refers to a UC volume rather than a local path.
+func IsVolumePath(p string) bool {
+	return strings.HasPrefix(NormalizePath(p), "/Volumes/")
+}
+
+// DownloadFile copies a volume file to a local path. The data is written to
+// "<local>.part" and renamed into place once complete, so an interrupted
+// download resumes from that file and never from an unrelated local one. With
+// overwrite set, an existing local file is replaced and the download restarts.
+func DownloadFile(ctx context.Context, w *databricks.WorkspaceClient, remote, local string, overwrite bool, progress ProgressFunc) error {
+	info, err := Stat(ctx, w, remote)
+	if err != nil {
+		return err
+	}
+	if info == nil || info.IsDirectory {
+		return fmt.Errorf("%s is not a file", remote)
+	}
+	if st, err := os.Stat(local); err == nil {
+		if st.IsDir() || !overwrite {
+			return fmt.Errorf("%s already exists", local)
+		}
+	}
+
+	part := local + ".part"
+	var offset int64
+	resumed := false
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if st, err := os.Stat(part); err == nil && !overwrite && st.Mode().IsRegular() && st.Size() <= info.Size {
+		offset = st.Size()
+		resumed = true
+		flags = os.O_WRONLY | os.O_APPEND
+	}
+
+	if !resumed || offset < info.Size {
+		// Only touch the partial file once the download has started, so a
+		// failed request leaves it as it was.
+		body, err := Download(ctx, w, remote, offset, 0)
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+
+		if err := os.MkdirAll(filepath.Dir(local), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(local), err)
+		}
+		if err := writePart(part, flags, body, progress, info.Size, offset); err != nil {
+			return fmt.Errorf("failed to download %s: %w", remote, err)
+		}
+	}
+
+	st, err := os.Stat(part)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", remote, err)
+	}
+	if st.Size() != info.Size {
+		return fmt.Errorf("failed to download %s: got %d of %d bytes", remote, st.Size(), info.Size)
+	}
+	if err := os.Rename(part, local); err != nil {
+		return fmt.Errorf("failed to move %s into place: %w", part, err)
+	}
+	return nil
+}
+
+// writePart copies body into the partial download file.
+func writePart(part string, flags int, body io.Reader, progress ProgressFunc, total, offset int64) error {
+	f, err := os.OpenFile(part, flags, 0644)
+	if err != nil {
+		return err
+	}
+	var dst io.Writer = f
+	if progress != nil {
+		if pw := progress(total, offset); pw != nil {
+			dst = io.MultiWriter(f, pw)
+		}
+	}
+	if _, err := io.Copy(dst, body); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// UploadFile streams a local file into a volume.
+func UploadFile(ctx context.Context, w *databricks.WorkspaceClient, local, remote string, overwrite bool, progress ProgressFunc) error {
+	f, err := os.Open(local)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", local, err)
+	}
+	defer f.Close()
+
+	st, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", local, err)
+	}
+
+	var src io.Reader = f
+	if progress != nil {
+		if pw := progress(st.Size(), 0); pw != nil {
+			src = io.TeeReader(f, pw)
+		}
+	}
+	return Upload(ctx, w, remote, src, overwrite)
+}
@@ -0,0 +1,136 @@
+package files
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDownloadFile(t *testing.T) {
+	api, w := newFakeFilesAPI(t)
+	ctx := context.Background()
+	remote := testVolume + "/report.csv"
+	api.put(remote, "a,b\n1,2\n3,4\n", time.Now())
+	local := filepath.Join(t.TempDir(), "out", "report.csv")
+
+	if err := DownloadFile(ctx, w, remote, local, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, local); got != "a,b\n1,2\n3,4\n" {
+		t.Errorf("download = %q", got)
+	}
+	if _, err := os.Stat(local + ".part"); !os.IsNotExist(err) {
+		t.Errorf("partial file left behind: %v", err)
+	}
+
+	// An interrupted download continues from the partial file.
+	if err := os.Remove(local); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(local+".part", []byte("a,b\n1,"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := DownloadFile(ctx, w, remote, local, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, local); got != "a,b\n1,2\n3,4\n" {
+		t.Errorf("resumed download = %q", got)
+	}
+
+	// A partial file longer than the remote one is discarded.
+	if err := os.Remove(local); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(local+".part", []byte("a,b\n1,2\n3,4\n5,6\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := DownloadFile(ctx, w, remote, local, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, local); got != "a,b\n1,2\n3,4\n" {
+		t.Errorf("download over a longer partial file = %q", got)
+	}
+}
+
+func TestDownloadFileExistingLocalFile(t *testing.T) {
+	api, w := newFakeFilesAPI(t)
+	ctx := context.Background()
+	remote := testVolume + "/report.csv"
+	const content = "a,b\n1,2\n3,4\n"
+	api.put(remote, content, time.Now())
+
+	tests := []struct {
+		name  string
+		local string
+	}{
+		{"smaller", "x,y\n"},
+		{"equal size", "x,y\n9,9\n9,9\n"},
+		{"larger", "x,y\n9,9\n9,9\n9,9\n9,9\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local := filepath.Join(t.TempDir(), "report.csv")
+			if err := os.WriteFile(local, []byte(tt.local), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := DownloadFile(ctx, w, remote, local, false, nil); err == nil {
+				t.Error("expected an error without overwrite")
+			}
+			if got := readFile(t, local); got != tt.local {
+				t.Errorf("local file changed to %q", got)
+			}
+
+			if err := DownloadFile(ctx, w, remote, local, true, nil); err != nil {
+				t.Fatal(err)
+			}
+			if got := readFile(t, local); got != content {
+				t.Errorf("overwritten file = %q", got)
+			}
+		})
+	}
+}
+
+func TestDownloadFileFailureKeepsLocalFile(t *testing.T) {
+	api, w := newFakeFilesAPI(t)
+	ctx := context.Background()
+	remote := testVolume + "/report.csv"
+	api.put(remote, "new content", time.Now())
+	local := filepath.Join(t.TempDir(), "report.csv")
+	if err := os.WriteFile(local, []byte("precious local data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	api.getError = http.StatusForbidden
+	for _, overwrite := range []bool{false, true} {
+		if err := DownloadFile(ctx, w, remote, local, overwrite, nil); err == nil {
+			t.Fatalf("overwrite=%v: expected an error", overwrite)
+		}
+		data, err := os.ReadFile(local)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "precious local data" {
+			t.Errorf("overwrite=%v: local file changed to %q", overwrite, data)
+		}
+	}
+
+	if err := DownloadFile(ctx, w, testVolume+"/missing.csv", local, false, nil); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	if data, _ := os.ReadFile(local); string(data) != "precious local data" {
+		t.Errorf("local file changed to %q", data)
+	}
+}
+
+func readFile(t *testing.T, p string) string {
+	t.Helper()
+	data, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
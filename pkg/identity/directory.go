@@ -0,0 +1,191 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/iam"
+)
+
+// PrincipalKind is the SCIM entity type behind a Unity Catalog principal name.
+type PrincipalKind string
+
+const (
+	KindUser             PrincipalKind = "user"
+	KindGroup            PrincipalKind = "group"
+	KindServicePrincipal PrincipalKind = "service principal"
+)
+
+// ImplicitGroups are the built-in groups every user and service principal
+// belongs to without SCIM listing the membership.
+var ImplicitGroups = []string{"account users", "users"}
+
+// Principal is a resolved user, group or service principal.
+type Principal struct {
+	Name   string
+	Kind   PrincipalKind
+	Active bool
+	// Groups holds the display names of every group the principal belongs to,
+	// including groups inherited through nested membership and, for users and
+	// service principals, the ImplicitGroups.
+	Groups []string
+}
+
+// Directory resolves principal names against the workspace SCIM API. Lookups
+// are cached, so a Directory can be shared across a whole crawl.
+type Directory struct {
+	w          *databricks.WorkspaceClient
+	principals map[string]*Principal
+	groupNames map[string]string
+	parents    map[string][]iam.ComplexValue
+}
+
+// NewDirectory creates a Directory backed by the given workspace client.
+func NewDirectory(w *databricks.WorkspaceClient) *Directory {
+	return &Directory{
+		w:          w,
+		principals: make(map[string]*Principal),
+		groupNames: make(map[string]string),
+		parents:    make(map[string][]iam.ComplexValue),
+	}
+}
+
+// Lookup resolves a principal as it appears in grants: a user name (email), a
+// group display name or a service principal application ID. It returns nil
+// without an error if no such principal exists.
+func (d *Directory) Lookup(ctx context.Context, name string) (*Principal, error) {
+	if p, ok := d.principals[name]; ok {
+		return p, nil
+	}
+
+	p, err := d.lookup(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	d.principals[name] = p
+	return p, nil
+}
+
+func (d *Directory) lookup(ctx context.Context, name string) (*Principal, error) {
+	if strings.Contains(name, "@") {
+		return d.lookupUser(ctx, name)
+	}
+
+	if p, err := d.lookupGroup(ctx, name); err != nil || p != nil {
+		return p, err
+	}
+	if p, err := d.lookupServicePrincipal(ctx, name); err != nil || p != nil {
+		return p, err
+	}
+	return d.lookupUser(ctx, name)
+}
+
+func (d *Directory) lookupUser(ctx context.Context, name string) (*Principal, error) {
+	it := d.w.Users.List(ctx, iam.ListUsersRequest{Filter: eqFilter("userName", name)})
+	for it.HasNext(ctx) {
+		u, err := it.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up user %s: %w", name, err)
+		}
+		groups, err := d.expandGroups(ctx, u.Groups)
+		if err != nil {
+			return nil, err
+		}
+		return &Principal{Name: u.UserName, Kind: KindUser, Active: u.Active, Groups: withImplicitGroups(groups)}, nil
+	}
+	return nil, nil
+}
+
+func (d *Directory) lookupGroup(ctx context.Context, name string) (*Principal, error) {
+	it := d.w.Groups.List(ctx, iam.ListGroupsRequest{Filter: eqFilter("displayName", name)})
+	for it.HasNext(ctx) {
+		g, err := it.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up group %s: %w", name, err)
+		}
+		groups, err := d.expandGroups(ctx, g.Groups)
+		if err != nil {
+			return nil, err
+		}
+		return &Principal{Name: g.DisplayName, Kind: KindGroup, Active: true, Groups: groups}, nil
+	}
+	return nil, nil
+}
+
+func (d *Directory) lookupServicePrincipal(ctx context.Context, name string) (*Principal, error) {
+	it := d.w.ServicePrincipals.List(ctx, iam.ListServicePrincipalsRequest{Filter: eqFilter("applicationId", name)})
+	for it.HasNext(ctx) {
+		sp, err := it.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up service principal %s: %w", name, err)
+		}
+		groups, err := d.expandGroups(ctx, sp.Groups)
+		if err != nil {
+			return nil, err
+		}
+		return &Principal{Name: sp.ApplicationId, Kind: KindServicePrincipal, Active: sp.Active, Groups: withImplicitGroups(groups)}, nil
+	}
+	return nil, nil
+}
+
+// expandGroups follows parent group links breadth-first and returns the display
+// names of all direct and nested groups.
+func (d *Directory) expandGroups(ctx context.Context, direct []iam.ComplexValue) ([]string, error) {
+	seen := make(map[string]bool)
+	queue := append([]iam.ComplexValue(nil), direct...)
+	var names []string
+
+	for len(queue) > 0 {
+		g := queue[0]
+		queue = queue[1:]
+		if g.Value == "" || seen[g.Value] {
+			continue
+		}
+		seen[g.Value] = true
+
+		parents, name, err := d.groupParents(ctx, g)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		queue = append(queue, parents...)
+	}
+	return names, nil
+}
+
+// withImplicitGroups appends the ImplicitGroups not already listed.
+func withImplicitGroups(groups []string) []string {
+	for _, implicit := range ImplicitGroups {
+		found := false
+		for _, g := range groups {
+			if strings.EqualFold(g, implicit) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, implicit)
+		}
+	}
+	return groups
+}
+
+func (d *Directory) groupParents(ctx context.Context, g iam.ComplexValue) ([]iam.ComplexValue, string, error) {
+	if parents, ok := d.parents[g.Value]; ok {
+		return parents, d.groupNames[g.Value], nil
+	}
+
+	group, err := d.w.Groups.GetById(ctx, g.Value)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get group %s: %w", g.Display, err)
+	}
+	d.parents[g.Value] = group.Groups
+	d.groupNames[g.Value] = group.DisplayName
+	return group.Groups, group.DisplayName, nil
+}
+
+func eqFilter(attribute, value string) string {
+	return fmt.Sprintf(`%s eq "%s"`, attribute, strings.ReplaceAll(value, `"`, `\"`))
+}
@@ -0,0 +1,119 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go"
+)
+
+// fakeSCIM serves the workspace SCIM list and get endpoints from fixed
+// resources, answering "attr eq value" filters.
+type fakeSCIM struct {
+	users, groups, servicePrincipals []map[string]interface{}
+}
+
+var filterRe = regexp.MustCompile(`^(\w+) eq "(.*)"$`)
+
+func (f *fakeSCIM) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	const prefix = "/api/2.0/preview/scim/v2/"
+	resource := strings.TrimPrefix(r.URL.Path, prefix)
+	var list []map[string]interface{}
+	switch {
+	case resource == "Users":
+		list = f.users
+	case resource == "Groups":
+		list = f.groups
+	case resource == "ServicePrincipals":
+		list = f.servicePrincipals
+	case strings.HasPrefix(resource, "Groups/"):
+		for _, g := range f.groups {
+			if g["id"] == strings.TrimPrefix(resource, "Groups/") {
+				json.NewEncoder(rw).Encode(g)
+				return
+			}
+		}
+		rw.WriteHeader(http.StatusNotFound)
+		return
+	default:
+		rw.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var matches []map[string]interface{}
+	if start := r.URL.Query().Get("startIndex"); start == "" || start == "1" {
+		m := filterRe.FindStringSubmatch(r.URL.Query().Get("filter"))
+		for _, item := range list {
+			if m != nil && item[m[1]] == m[2] {
+				matches = append(matches, item)
+			}
+		}
+	}
+	json.NewEncoder(rw).Encode(map[string]interface{}{"Resources": matches, "startIndex": 1, "totalResults": len(matches)})
+}
+
+func newFakeDirectory(t *testing.T, f *fakeSCIM) *Directory {
+	t.Helper()
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	w, err := databricks.NewWorkspaceClient(&databricks.Config{Host: srv.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewDirectory(w)
+}
+
+func TestLookup(t *testing.T) {
+	member := []map[string]string{{"value": "g1", "display": "analysts"}}
+	d := newFakeDirectory(t, &fakeSCIM{
+		groups: []map[string]interface{}{
+			{"id": "g1", "displayName": "analysts", "groups": []map[string]string{{"value": "g2"}}},
+			{"id": "g2", "displayName": "data"},
+			{"id": "g3", "displayName": "shared-name"},
+		},
+		servicePrincipals: []map[string]interface{}{
+			{"applicationId": "1234-abcd", "active": true, "groups": member},
+			{"applicationId": "shared-name", "active": true},
+		},
+		users: []map[string]interface{}{
+			{"userName": "jane@example.com", "active": true, "groups": member},
+			{"userName": "legacy", "active": false},
+		},
+	})
+
+	tests := []struct {
+		name   string
+		kind   PrincipalKind
+		groups []string
+	}{
+		{"analysts", KindGroup, []string{"data"}},
+		// A name without "@" is tried as a group before a service principal.
+		{"shared-name", KindGroup, nil},
+		{"1234-abcd", KindServicePrincipal, []string{"analysts", "data", "account users", "users"}},
+		{"jane@example.com", KindUser, []string{"analysts", "data", "account users", "users"}},
+		// Finally as a user name.
+		{"legacy", KindUser, []string{"account users", "users"}},
+	}
+	for _, tt := range tests {
+		p, err := d.Lookup(context.Background(), tt.name)
+		if err != nil {
+			t.Fatalf("Lookup(%s): %v", tt.name, err)
+		}
+		if p == nil {
+			t.Fatalf("Lookup(%s) = nil", tt.name)
+		}
+		if p.Kind != tt.kind || !reflect.DeepEqual(p.Groups, tt.groups) {
+			t.Errorf("Lookup(%s) = %s %v, want %s %v", tt.name, p.Kind, p.Groups, tt.kind, tt.groups)
+		}
+	}
+
+	if p, err := d.Lookup(context.Background(), "nobody@example.com"); err != nil || p != nil {
+		t.Errorf("Lookup(nobody) = %v, %v; want nil, nil", p, err)
+	}
+}
@@ -0,0 +1,102 @@
+package query
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+)
+
+// QuoteString quotes a SQL string literal.
+func QuoteString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+// CommentStatement returns the statement that sets the comment of a table, or
+// of one of its columns when column is set. An empty comment clears it.
+func CommentStatement(fullName, column, comment string) string {
+	if column == "" {
+		value := "NULL"
+		if comment != "" {
+			value = QuoteString(comment)
+		}
+		return fmt.Sprintf("COMMENT ON TABLE %s IS %s", QuoteName(fullName), value)
+	}
+	return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s COMMENT %s", QuoteName(fullName), QuoteIdent(column), QuoteString(comment))
+}
+
+// SetComment sets the comment of a table or column on a SQL warehouse.
+func SetComment(ctx context.Context, w *databricks.WorkspaceClient, warehouseID, fullName, column, comment string) error {
+	_, err := Execute(ctx, w, warehouseID, CommentStatement(fullName, column, comment))
+	return err
+}
+
+// CommentEntry is one row of a comments file. Column is empty for the table
+// comment.
+type CommentEntry struct {
+	Line    int
+	Table   string
+	Column  string
+	Comment string
+}
+
+// ReadCommentsCSV reads a comments file with a header row naming the table,
+// column and comment fields in any order. The column field may be left out
+// for a file of table comments.
+func ReadCommentsCSV(r io.Reader) ([]CommentEntry, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("comments file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read comments file: %w", err)
+	}
+
+	index := map[string]int{"table": -1, "column": -1, "comment": -1}
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		if _, ok := index[h]; ok {
+			index[h] = i
+		}
+	}
+	if index["table"] < 0 || index["comment"] < 0 {
+		return nil, errors.New("comments file needs a header with table, column and comment")
+	}
+	field := func(record []string, name string) string {
+		if i := index[name]; i >= 0 && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	var entries []CommentEntry
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read comments file: %w", err)
+		}
+		e := CommentEntry{
+			Line:    line,
+			Table:   strings.TrimSpace(field(record, "table")),
+			Column:  strings.TrimSpace(field(record, "column")),
+			Comment: strings.TrimSpace(field(record, "comment")),
+		}
+		if e.Table == "" {
+			return nil, fmt.Errorf("line %d: table is empty", line)
+		}
+		if strings.Count(e.Table, ".") != 2 {
+			return nil, fmt.Errorf("line %d: expected catalog.schema.table, got %q", line, e.Table)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
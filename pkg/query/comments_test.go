@@ -0,0 +1,45 @@
+package query
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCommentStatement(t *testing.T) {
+	tests := []struct {
+		column, comment, want string
+	}{
+		{"", "Orders placed online", "COMMENT ON TABLE `main`.`sales`.`orders` IS 'Orders placed online'"},
+		{"", "", "COMMENT ON TABLE `main`.`sales`.`orders` IS NULL"},
+		{"id", `Customer's id \ key`, "ALTER TABLE `main`.`sales`.`orders` ALTER COLUMN `id` COMMENT 'Customer\\'s id \\\\ key'"},
+	}
+	for _, tt := range tests {
+		if got := CommentStatement("main.sales.orders", tt.column, tt.comment); got != tt.want {
+			t.Errorf("CommentStatement(%q, %q) = %s, want %s", tt.column, tt.comment, got, tt.want)
+		}
+	}
+}
+
+func TestReadCommentsCSV(t *testing.T) {
+	in := "\ufeffComment,Table,Column\n" +
+		"\"Orders, one row per order\",main.sales.orders,\n" +
+		"Buyer,main.sales.orders,customer_id\n"
+	got, err := ReadCommentsCSV(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []CommentEntry{
+		{Line: 2, Table: "main.sales.orders", Comment: "Orders, one row per order"},
+		{Line: 3, Table: "main.sales.orders", Column: "customer_id", Comment: "Buyer"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadCommentsCSV() = %+v, want %+v", got, want)
+	}
+
+	for _, bad := range []string{"", "name,comment\n", "table,comment\norders,x\n"} {
+		if _, err := ReadCommentsCSV(strings.NewReader(bad)); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
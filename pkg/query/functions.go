@@ -0,0 +1,85 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	pkgcatalog "dbx-explore/pkg/catalog"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/databricks-sdk-go/service/sql"
+)
+
+// Argument is the value passed for one function parameter. A nil Value
+// passes NULL.
+type Argument struct {
+	Name  string
+	Value *string
+}
+
+// parameterTypes are the types the Statement Execution API can bind directly.
+// Anything else is sent as a string and converted in SQL.
+var parameterTypes = map[string]bool{
+	"BOOLEAN": true, "TINYINT": true, "SMALLINT": true, "INT": true, "BIGINT": true,
+	"FLOAT": true, "DOUBLE": true, "DECIMAL": true, "STRING": true,
+	"DATE": true, "TIMESTAMP": true, "TIMESTAMP_NTZ": true,
+}
+
+// FunctionCall builds a statement invoking fn with named arguments, each bound
+// as a statement parameter. Scalar functions are called with SELECT fn(...),
+// table functions with SELECT * FROM fn(...). Parameters missing from args
+// fall back to their declared default.
+func FunctionCall(fn catalog.FunctionInfo, args []Argument) (string, []sql.StatementParameterListItem) {
+	types := make(map[string]catalog.FunctionParameterInfo)
+	for _, p := range pkgcatalog.FunctionParams(fn.InputParams) {
+		types[p.Name] = p
+	}
+
+	var named []string
+	var params []sql.StatementParameterListItem
+	for i, a := range args {
+		marker := fmt.Sprintf("arg%d", i)
+		item := sql.StatementParameterListItem{Name: marker, Type: "STRING"}
+		if a.Value != nil {
+			// An omitted value binds NULL, so send empty strings explicitly.
+			item.Value = *a.Value
+			item.ForceSendFields = []string{"Value"}
+		}
+
+		expr := ":" + marker
+		p := types[a.Name]
+		typeText := strings.ToUpper(p.TypeText)
+		baseType, _, _ := strings.Cut(typeText, "(")
+		switch {
+		case p.TypeName == catalog.ColumnTypeNameArray || p.TypeName == catalog.ColumnTypeNameMap || p.TypeName == catalog.ColumnTypeNameStruct:
+			// Complex values are entered as JSON.
+			expr = fmt.Sprintf("from_json(%s, '%s')", expr, strings.ReplaceAll(p.TypeText, "'", "\\'"))
+		case parameterTypes[baseType]:
+			item.Type = typeText
+		case typeText != "":
+			expr = fmt.Sprintf("CAST(%s AS %s)", expr, typeText)
+		}
+		params = append(params, item)
+		named = append(named, fmt.Sprintf("%s => %s", QuoteIdent(a.Name), expr))
+	}
+
+	call := fmt.Sprintf("%s(%s)", QuoteName(fn.FullName), strings.Join(named, ", "))
+	if pkgcatalog.IsTableFunction(fn) {
+		return "SELECT * FROM " + call, params
+	}
+	return "SELECT " + call, params
+}
+
+// QuoteIdent quotes a single identifier with backticks.
+func QuoteIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// QuoteName quotes every part of a dotted name such as catalog.schema.table.
+func QuoteName(fullName string) string {
+	parts := strings.Split(fullName, ".")
+	for i, p := range parts {
+		parts[i] = QuoteIdent(p)
+	}
+	return strings.Join(parts, ".")
+}
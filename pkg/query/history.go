@@ -0,0 +1,131 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go"
+)
+
+// HistoryEntry is one commit from DESCRIBE HISTORY.
+type HistoryEntry struct {
+	Version    int64
+	Timestamp  string
+	User       string
+	Operation  string
+	Parameters map[string]string
+	Metrics    map[string]string
+	Job        string
+	Notebook   string
+	EngineInfo string
+}
+
+// TableHistory returns the most recent commits of a Delta table, newest first.
+func TableHistory(ctx context.Context, w *databricks.WorkspaceClient, warehouseID, fullName string, limit int) ([]HistoryEntry, error) {
+	statement := fmt.Sprintf("DESCRIBE HISTORY %s", QuoteName(fullName))
+	if limit > 0 {
+		statement += fmt.Sprintf(" LIMIT %d", limit)
+	}
+	res, err := Execute(ctx, w, warehouseID, statement)
+	if err != nil {
+		return nil, err
+	}
+
+	col := make(map[string]int)
+	for i, c := range res.Columns {
+		col[c] = i
+	}
+	get := func(row []string, name string) string {
+		if i, ok := col[name]; ok && i < len(row) {
+			return row[i]
+		}
+		return ""
+	}
+
+	var entries []HistoryEntry
+	for _, row := range res.Rows {
+		version, _ := strconv.ParseInt(get(row, "version"), 10, 64)
+		user := get(row, "userName")
+		if user == "" {
+			user = get(row, "userId")
+		}
+		entries = append(entries, HistoryEntry{
+			Version:    version,
+			Timestamp:  get(row, "timestamp"),
+			User:       user,
+			Operation:  get(row, "operation"),
+			Parameters: parseStringMap(get(row, "operationParameters")),
+			Metrics:    parseStringMap(get(row, "operationMetrics")),
+			Job:        get(row, "job"),
+			Notebook:   get(row, "notebook"),
+			EngineInfo: get(row, "engineInfo"),
+		})
+	}
+	return entries, nil
+}
+
+// parseStringMap decodes a MAP<STRING,STRING> value returned as JSON.
+func parseStringMap(s string) map[string]string {
+	m := make(map[string]string)
+	if s == "" {
+		return m
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &raw); err != nil {
+		return m
+	}
+	for k, v := range raw {
+		if str, ok := v.(string); ok {
+			m[k] = str
+		} else {
+			b, _ := json.Marshal(v)
+			m[k] = string(b)
+		}
+	}
+	return m
+}
+
+// FormatMetrics renders a metrics map as "k=v" pairs sorted by key.
+func FormatMetrics(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%s", k, m[k])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// timestampLayouts are the accepted inputs for TIMESTAMP AS OF.
+var timestampLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+
+// AsOfVersion builds a VERSION AS OF time-travel clause.
+func AsOfVersion(version string) (string, error) {
+	v, err := strconv.ParseInt(strings.TrimSpace(version), 10, 64)
+	if err != nil || v < 0 {
+		return "", fmt.Errorf("invalid version %q", version)
+	}
+	return fmt.Sprintf("VERSION AS OF %d", v), nil
+}
+
+// AsOfTimestamp builds a TIMESTAMP AS OF time-travel clause. The timestamp is
+// parsed first so only well-formed values reach the statement. Timestamps
+// without an offset are UTC; the literal always carries an explicit offset so
+// the warehouse session time zone does not shift it.
+func AsOfTimestamp(ts string) (string, error) {
+	ts = strings.TrimSpace(ts)
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, ts); err == nil {
+			return fmt.Sprintf("TIMESTAMP AS OF '%s'", t.Format("2006-01-02 15:04:05-07:00")), nil
+		}
+	}
+	return "", fmt.Errorf("invalid timestamp %q: use YYYY-MM-DD or YYYY-MM-DD HH:MM:SS", ts)
+}
@@ -0,0 +1,50 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAsOfVersion(t *testing.T) {
+	if got, err := AsOfVersion(" 12 "); err != nil || got != "VERSION AS OF 12" {
+		t.Errorf("AsOfVersion(12) = %q, %v", got, err)
+	}
+	for _, in := range []string{"", "-1", "v3", "1.5"} {
+		if _, err := AsOfVersion(in); err == nil {
+			t.Errorf("AsOfVersion(%q): expected an error", in)
+		}
+	}
+}
+
+func TestAsOfTimestamp(t *testing.T) {
+	tests := map[string]string{
+		"2024-03-01":                "TIMESTAMP AS OF '2024-03-01 00:00:00+00:00'",
+		"2024-03-01 10:30:00":       "TIMESTAMP AS OF '2024-03-01 10:30:00+00:00'",
+		"2024-03-01T10:30:00":       "TIMESTAMP AS OF '2024-03-01 10:30:00+00:00'",
+		"2024-03-01T10:30:00Z":      "TIMESTAMP AS OF '2024-03-01 10:30:00+00:00'",
+		"2024-03-01T10:30:00+02:00": "TIMESTAMP AS OF '2024-03-01 10:30:00+02:00'",
+	}
+	for in, want := range tests {
+		if got, err := AsOfTimestamp(in); err != nil || got != want {
+			t.Errorf("AsOfTimestamp(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"yesterday", "2024-13-01", "2024-03-01'; DROP TABLE x; --"} {
+		if _, err := AsOfTimestamp(in); err == nil {
+			t.Errorf("AsOfTimestamp(%q): expected an error", in)
+		}
+	}
+}
+
+func TestParseStringMap(t *testing.T) {
+	got := parseStringMap(`{"mode":"Append","numFiles":3,"partitionBy":["day"],"empty":null}`)
+	want := map[string]string{"mode": "Append", "numFiles": "3", "partitionBy": `["day"]`, "empty": "null"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseStringMap = %v, want %v", got, want)
+	}
+	for _, in := range []string{"", "not json"} {
+		if got := parseStringMap(in); got == nil || len(got) != 0 {
+			t.Errorf("parseStringMap(%q) = %v, want an empty map", in, got)
+		}
+	}
+}
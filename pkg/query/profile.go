@@ -0,0 +1,197 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+// topK is the number of most frequent values reported for string columns.
+const topK = 5
+
+// ColumnClass groups column types that share profiling statistics.
+type ColumnClass string
+
+const (
+	ClassNumeric  ColumnClass = "numeric"
+	ClassTemporal ColumnClass = "temporal"
+	ClassString   ColumnClass = "string"
+	ClassBoolean  ColumnClass = "boolean"
+	ClassOther    ColumnClass = "other"
+)
+
+// ClassifyColumn returns the profiling class of a column type.
+func ClassifyColumn(t catalog.ColumnTypeName) ColumnClass {
+	switch t {
+	case catalog.ColumnTypeNameByte, catalog.ColumnTypeNameShort, catalog.ColumnTypeNameInt,
+		catalog.ColumnTypeNameLong, catalog.ColumnTypeNameFloat, catalog.ColumnTypeNameDouble,
+		catalog.ColumnTypeNameDecimal:
+		return ClassNumeric
+	case catalog.ColumnTypeNameDate, catalog.ColumnTypeNameTimestamp, catalog.ColumnTypeNameTimestampNtz:
+		return ClassTemporal
+	case catalog.ColumnTypeNameString, catalog.ColumnTypeNameChar:
+		return ClassString
+	case catalog.ColumnTypeNameBoolean:
+		return ClassBoolean
+	default:
+		return ClassOther
+	}
+}
+
+// TopValue is a frequent value and its approximate count.
+type TopValue struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// ColumnProfile holds the statistics of one column. Fields that do not apply
+// to the column's class are empty.
+type ColumnProfile struct {
+	Name      string      `json:"name"`
+	Type      string      `json:"type"`
+	Class     ColumnClass `json:"class"`
+	Nulls     int64       `json:"nulls"`
+	NullPct   float64     `json:"null_pct"`
+	Distinct  int64       `json:"approx_distinct"`
+	Min       string      `json:"min,omitempty"`
+	Max       string      `json:"max,omitempty"`
+	Avg       string      `json:"avg,omitempty"`
+	MinLength string      `json:"min_length,omitempty"`
+	MaxLength string      `json:"max_length,omitempty"`
+	AvgLength string      `json:"avg_length,omitempty"`
+	TopValues []TopValue  `json:"top_values,omitempty"`
+}
+
+// TableProfile is the result of profiling a table.
+type TableProfile struct {
+	Table         string          `json:"table"`
+	Rows          int64           `json:"rows"`
+	SamplePercent float64         `json:"sample_percent,omitempty"`
+	Columns       []ColumnProfile `json:"columns"`
+}
+
+// ProfileStatement builds a single aggregate statement computing the
+// statistics of every column. Each value is aliased c<index>_<stat>.
+func ProfileStatement(fullName string, columns []catalog.ColumnInfo, samplePercent float64) string {
+	exprs := []string{"count(*) AS row_count"}
+	for i, c := range columns {
+		col := QuoteIdent(c.Name)
+		alias := func(stat string) string { return fmt.Sprintf("c%d_%s", i, stat) }
+
+		exprs = append(exprs, fmt.Sprintf("count_if(%s IS NULL) AS %s", col, alias("nulls")))
+		class := ClassifyColumn(c.TypeName)
+		if class != ClassOther {
+			exprs = append(exprs, fmt.Sprintf("approx_count_distinct(%s) AS %s", col, alias("distinct")))
+		}
+		switch class {
+		case ClassNumeric:
+			exprs = append(exprs,
+				fmt.Sprintf("min(%s) AS %s", col, alias("min")),
+				fmt.Sprintf("max(%s) AS %s", col, alias("max")),
+				fmt.Sprintf("avg(%s) AS %s", col, alias("avg")))
+		case ClassTemporal:
+			exprs = append(exprs,
+				fmt.Sprintf("min(%s) AS %s", col, alias("min")),
+				fmt.Sprintf("max(%s) AS %s", col, alias("max")))
+		case ClassString:
+			exprs = append(exprs,
+				fmt.Sprintf("min(length(%s)) AS %s", col, alias("minlen")),
+				fmt.Sprintf("max(length(%s)) AS %s", col, alias("maxlen")),
+				fmt.Sprintf("avg(length(%s)) AS %s", col, alias("avglen")),
+				fmt.Sprintf("to_json(approx_top_k(%s, %d)) AS %s", col, topK, alias("top")))
+		}
+	}
+
+	from := QuoteName(fullName)
+	if samplePercent > 0 {
+		from += fmt.Sprintf(" TABLESAMPLE (%g PERCENT)", samplePercent)
+	}
+	return fmt.Sprintf("SELECT\n  %s\nFROM %s", strings.Join(exprs, ",\n  "), from)
+}
+
+// ProfileTable runs the profile statement for a table on a SQL warehouse.
+func ProfileTable(ctx context.Context, w *databricks.WorkspaceClient, warehouseID, fullName string, columns []catalog.ColumnInfo, samplePercent float64) (*TableProfile, error) {
+	if samplePercent < 0 || samplePercent > 100 {
+		return nil, fmt.Errorf("sample percentage must be between 0 and 100, got %g", samplePercent)
+	}
+	res, err := Execute(ctx, w, warehouseID, ProfileStatement(fullName, columns, samplePercent))
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Rows) != 1 {
+		return nil, fmt.Errorf("profile query returned %d rows, expected 1", len(res.Rows))
+	}
+	return parseProfile(fullName, columns, samplePercent, res.Columns, res.Rows[0]), nil
+}
+
+func parseProfile(fullName string, columns []catalog.ColumnInfo, samplePercent float64, names, row []string) *TableProfile {
+	values := make(map[string]string, len(names))
+	for i, n := range names {
+		if i < len(row) {
+			values[n] = row[i]
+		}
+	}
+
+	p := &TableProfile{Table: fullName, SamplePercent: samplePercent}
+	p.Rows, _ = strconv.ParseInt(values["row_count"], 10, 64)
+	for i, c := range columns {
+		get := func(stat string) string { return values[fmt.Sprintf("c%d_%s", i, stat)] }
+		cp := ColumnProfile{
+			Name:      c.Name,
+			Type:      c.TypeText,
+			Class:     ClassifyColumn(c.TypeName),
+			Min:       get("min"),
+			Max:       get("max"),
+			Avg:       roundNumber(get("avg")),
+			MinLength: get("minlen"),
+			MaxLength: get("maxlen"),
+			AvgLength: roundNumber(get("avglen")),
+			TopValues: parseTopValues(get("top")),
+		}
+		cp.Nulls, _ = strconv.ParseInt(get("nulls"), 10, 64)
+		cp.Distinct, _ = strconv.ParseInt(get("distinct"), 10, 64)
+		if p.Rows > 0 {
+			cp.NullPct = float64(cp.Nulls) * 100 / float64(p.Rows)
+		}
+		p.Columns = append(p.Columns, cp)
+	}
+	return p
+}
+
+// parseTopValues decodes approx_top_k output: [{"item":...,"count":...}].
+func parseTopValues(s string) []TopValue {
+	if s == "" {
+		return nil
+	}
+	var raw []struct {
+		Item  interface{} `json:"item"`
+		Count int64       `json:"count"`
+	}
+	if err := json.Unmarshal([]byte(s), &raw); err != nil {
+		return nil
+	}
+	top := make([]TopValue, len(raw))
+	for i, r := range raw {
+		v := "NULL"
+		if r.Item != nil {
+			v = fmt.Sprintf("%v", r.Item)
+		}
+		top[i] = TopValue{Value: v, Count: r.Count}
+	}
+	return top
+}
+
+// roundNumber shortens averages to two decimals, dropping trailing zeros.
+func roundNumber(s string) string {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return s
+	}
+	r := strconv.FormatFloat(f, 'f', 2, 64)
+	return strings.TrimSuffix(strings.TrimRight(r, "0"), ".")
+}
@@ -0,0 +1,55 @@
+package query
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+var profileColumns = []catalog.ColumnInfo{
+	{Name: "id", TypeName: catalog.ColumnTypeNameLong, TypeText: "bigint"},
+	{Name: "region", TypeName: catalog.ColumnTypeNameString, TypeText: "string"},
+	{Name: "tags", TypeName: catalog.ColumnTypeNameArray, TypeText: "array<string>"},
+}
+
+func TestProfileStatement(t *testing.T) {
+	got := ProfileStatement("main.sales.orders", profileColumns, 1)
+	for _, want := range []string{
+		"count(*) AS row_count",
+		"count_if(`id` IS NULL) AS c0_nulls",
+		"avg(`id`) AS c0_avg",
+		"to_json(approx_top_k(`region`, 5)) AS c1_top",
+		"count_if(`tags` IS NULL) AS c2_nulls",
+		"FROM `main`.`sales`.`orders` TABLESAMPLE (1 PERCENT)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("statement missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "approx_count_distinct(`tags`)") {
+		t.Errorf("complex column should only count nulls:\n%s", got)
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	names := []string{"row_count", "c0_nulls", "c0_distinct", "c0_min", "c0_max", "c0_avg",
+		"c1_nulls", "c1_distinct", "c1_minlen", "c1_maxlen", "c1_avglen", "c1_top", "c2_nulls"}
+	row := []string{"200", "0", "198", "1", "200", "100.5",
+		"50", "3", "2", "5", "3.3333", `[{"item":"EU","count":90},{"item":null,"count":50}]`, "200"}
+
+	p := parseProfile("main.sales.orders", profileColumns, 0, names, row)
+	if p.Rows != 200 || len(p.Columns) != 3 {
+		t.Fatalf("rows = %d, columns = %d", p.Rows, len(p.Columns))
+	}
+	if c := p.Columns[0]; c.Distinct != 198 || c.Min != "1" || c.Avg != "100.5" {
+		t.Errorf("id profile = %+v", c)
+	}
+	c := p.Columns[1]
+	if c.NullPct != 25 || c.AvgLength != "3.33" || len(c.TopValues) != 2 || c.TopValues[1].Value != "NULL" {
+		t.Errorf("region profile = %+v", c)
+	}
+	if c := p.Columns[2]; c.NullPct != 100 || c.Class != ClassOther {
+		t.Errorf("tags profile = %+v", c)
+	}
+}
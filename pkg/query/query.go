@@ -0,0 +1,51 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/sql"
+)
+
+// Result is a fully fetched statement result with every value as a string.
+type Result struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// Execute runs a statement on a SQL warehouse through the Statement Execution
+// API, waits for it to finish and fetches all result chunks.
+func Execute(ctx context.Context, w *databricks.WorkspaceClient, warehouseID, statement string, params ...sql.StatementParameterListItem) (*Result, error) {
+	resp, err := w.StatementExecution.ExecuteAndWait(ctx, sql.ExecuteStatementRequest{
+		WarehouseId: warehouseID,
+		Statement:   statement,
+		Parameters:  params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+
+	res := &Result{}
+	if resp.Manifest != nil && resp.Manifest.Schema != nil {
+		for _, col := range resp.Manifest.Schema.Columns {
+			res.Columns = append(res.Columns, col.Name)
+		}
+	}
+
+	chunk := resp.Result
+	for chunk != nil {
+		res.Rows = append(res.Rows, chunk.DataArray...)
+		if chunk.NextChunkIndex == 0 {
+			break
+		}
+		chunk, err = w.StatementExecution.GetStatementResultChunkN(ctx, sql.GetStatementResultChunkNRequest{
+			StatementId: resp.StatementId,
+			ChunkIndex:  chunk.NextChunkIndex,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch result chunk: %w", err)
+		}
+	}
+	return res, nil
+}
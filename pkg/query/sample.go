@@ -0,0 +1,153 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultSampleLimit is the row limit of a sample nobody has configured yet.
+const DefaultSampleLimit = 5
+
+// maxSampleLimit keeps accidental huge samples from flooding the terminal.
+const maxSampleLimit = 10000
+
+// SampleSpec describes which rows of a table to sample.
+type SampleSpec struct {
+	// Columns to select; empty selects every column.
+	Columns []string `json:"columns,omitempty"`
+	Limit   int      `json:"limit"`
+	// Where is a raw SQL predicate typed by the user.
+	Where      string `json:"where,omitempty"`
+	OrderBy    string `json:"order_by,omitempty"`
+	Descending bool   `json:"descending,omitempty"`
+	// SamplePercent adds TABLESAMPLE (n PERCENT) when greater than zero.
+	SamplePercent float64 `json:"sample_percent,omitempty"`
+}
+
+// DefaultSampleSpec returns the classic SELECT * ... LIMIT 5 sample.
+func DefaultSampleSpec() SampleSpec {
+	return SampleSpec{Limit: DefaultSampleLimit}
+}
+
+// Validate checks the limit, sample percentage and predicate.
+func (s SampleSpec) Validate() error {
+	if s.Limit < 1 || s.Limit > maxSampleLimit {
+		return fmt.Errorf("limit must be between 1 and %d", maxSampleLimit)
+	}
+	if s.SamplePercent < 0 || s.SamplePercent > 100 {
+		return fmt.Errorf("sample percentage must be between 0 and 100")
+	}
+	// The predicate cannot be quoted, but it must stay a single expression.
+	if strings.Contains(s.Where, ";") {
+		return fmt.Errorf("the WHERE predicate must not contain ';'")
+	}
+	return nil
+}
+
+// SQL builds the sample statement for a table. Identifiers are quoted; asOf is
+// an optional time-travel clause from AsOfVersion or AsOfTimestamp.
+func (s SampleSpec) SQL(fullName, asOf string) (string, error) {
+	if err := s.Validate(); err != nil {
+		return "", err
+	}
+
+	cols := "*"
+	if len(s.Columns) > 0 {
+		quoted := make([]string, len(s.Columns))
+		for i, c := range s.Columns {
+			quoted[i] = QuoteIdent(c)
+		}
+		cols = strings.Join(quoted, ", ")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "SELECT %s FROM %s", cols, QuoteName(fullName))
+	if asOf != "" {
+		b.WriteString(" " + asOf)
+	}
+	if s.SamplePercent > 0 {
+		fmt.Fprintf(&b, " TABLESAMPLE (%g PERCENT)", s.SamplePercent)
+	}
+	if where := strings.TrimSpace(s.Where); where != "" {
+		fmt.Fprintf(&b, " WHERE %s", where)
+	}
+	if s.OrderBy != "" {
+		fmt.Fprintf(&b, " ORDER BY %s", QuoteIdent(s.OrderBy))
+		if s.Descending {
+			b.WriteString(" DESC")
+		}
+	}
+	fmt.Fprintf(&b, " LIMIT %d", s.Limit)
+	return b.String(), nil
+}
+
+// Summary describes the spec in a few words for menus.
+func (s SampleSpec) Summary() string {
+	parts := []string{fmt.Sprintf("limit %d", s.Limit)}
+	if len(s.Columns) > 0 {
+		parts = append(parts, fmt.Sprintf("%d columns", len(s.Columns)))
+	}
+	if s.Where != "" {
+		parts = append(parts, "where "+s.Where)
+	}
+	if s.OrderBy != "" {
+		order := "order by " + s.OrderBy
+		if s.Descending {
+			order += " desc"
+		}
+		parts = append(parts, order)
+	}
+	if s.SamplePercent > 0 {
+		parts = append(parts, fmt.Sprintf("%g%% sample", s.SamplePercent))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// SampleStore remembers the last sample spec used for each table.
+type SampleStore struct {
+	Tables map[string]SampleSpec `json:"tables"`
+
+	path string
+}
+
+// LoadSampleStore reads the store at path; a missing file gives an empty store.
+func LoadSampleStore(path string) (*SampleStore, error) {
+	store := &SampleStore{Tables: make(map[string]SampleSpec), path: path}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if store.Tables == nil {
+		store.Tables = make(map[string]SampleSpec)
+	}
+	return store, nil
+}
+
+// Get returns the remembered spec for a table, or the default spec.
+func (s *SampleStore) Get(fullName string) SampleSpec {
+	if spec, ok := s.Tables[strings.ToLower(fullName)]; ok {
+		return spec
+	}
+	return DefaultSampleSpec()
+}
+
+// Set remembers a spec for a table and saves the store.
+func (s *SampleStore) Set(fullName string, spec SampleSpec) error {
+	s.Tables[strings.ToLower(fullName)] = spec
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode sample settings: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", s.path, err)
+	}
+	return nil
+}
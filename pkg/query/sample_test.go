@@ -0,0 +1,53 @@
+package query
+
+import "testing"
+
+func TestSampleSpecSQL(t *testing.T) {
+	tests := []struct {
+		name string
+		spec SampleSpec
+		asOf string
+		want string
+	}{
+		{
+			name: "default",
+			spec: DefaultSampleSpec(),
+			want: "SELECT * FROM `main`.`sales`.`orders` LIMIT 5",
+		},
+		{
+			name: "projection filter and order",
+			spec: SampleSpec{Columns: []string{"id", "odd`name"}, Limit: 20, Where: "amount > 100", OrderBy: "created at", Descending: true},
+			want: "SELECT `id`, `odd``name` FROM `main`.`sales`.`orders` WHERE amount > 100 ORDER BY `created at` DESC LIMIT 20",
+		},
+		{
+			name: "time travel and tablesample",
+			spec: SampleSpec{Limit: 10, SamplePercent: 2.5},
+			asOf: "VERSION AS OF 3",
+			want: "SELECT * FROM `main`.`sales`.`orders` VERSION AS OF 3 TABLESAMPLE (2.5 PERCENT) LIMIT 10",
+		},
+	}
+	for _, tt := range tests {
+		got, err := tt.spec.SQL("main.sales.orders", tt.asOf)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s:\n got %s\nwant %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSampleSpecValidate(t *testing.T) {
+	invalid := []SampleSpec{
+		{Limit: 0},
+		{Limit: maxSampleLimit + 1},
+		{Limit: 5, SamplePercent: 101},
+		{Limit: 5, Where: "1=1; DROP TABLE x"},
+	}
+	for _, spec := range invalid {
+		if err := spec.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want error", spec)
+		}
+	}
+}
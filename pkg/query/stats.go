@@ -0,0 +1,206 @@
+package query
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go"
+)
+
+// TableStats are the size statistics of a table from DESCRIBE DETAIL plus a
+// row count.
+type TableStats struct {
+	Table        string    `json:"table"`
+	Format       string    `json:"format,omitempty"`
+	Rows         int64     `json:"rows"`
+	SizeInBytes  int64     `json:"size_in_bytes"`
+	NumFiles     int64     `json:"num_files"`
+	LastModified string    `json:"last_modified,omitempty"`
+	CollectedAt  time.Time `json:"collected_at"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// CollectTableStats runs DESCRIBE DETAIL and a row count for one table.
+func CollectTableStats(ctx context.Context, w *databricks.WorkspaceClient, warehouseID, fullName string) (*TableStats, error) {
+	detail, err := Execute(ctx, w, warehouseID, "DESCRIBE DETAIL "+QuoteName(fullName))
+	if err != nil {
+		return nil, err
+	}
+	if len(detail.Rows) != 1 {
+		return nil, fmt.Errorf("DESCRIBE DETAIL returned %d rows, expected 1", len(detail.Rows))
+	}
+	field := func(name string) string {
+		for i, c := range detail.Columns {
+			if c == name && i < len(detail.Rows[0]) {
+				return detail.Rows[0][i]
+			}
+		}
+		return ""
+	}
+
+	s := &TableStats{
+		Table:        fullName,
+		Format:       field("format"),
+		LastModified: field("lastModified"),
+		CollectedAt:  time.Now().UTC(),
+	}
+	s.SizeInBytes, _ = strconv.ParseInt(field("sizeInBytes"), 10, 64)
+	s.NumFiles, _ = strconv.ParseInt(field("numFiles"), 10, 64)
+
+	count, err := Execute(ctx, w, warehouseID, "SELECT count(*) FROM "+QuoteName(fullName))
+	if err != nil {
+		return nil, err
+	}
+	if len(count.Rows) == 1 && len(count.Rows[0]) == 1 {
+		s.Rows, _ = strconv.ParseInt(count.Rows[0][0], 10, 64)
+	}
+	return s, nil
+}
+
+// CollectSchemaStats collects the stats of many tables on at most concurrency
+// goroutines. Tables that fail get an entry with Error set. onDone, if not
+// nil, is called after each table and must be safe for concurrent use.
+func CollectSchemaStats(ctx context.Context, w *databricks.WorkspaceClient, warehouseID string, tables []string, concurrency int, onDone func(TableStats)) []TableStats {
+	if concurrency < 1 {
+		concurrency = 4
+	}
+	stats := make([]TableStats, len(tables))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency && i < len(tables); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				s, err := CollectTableStats(ctx, w, warehouseID, tables[j])
+				if err != nil {
+					s = &TableStats{Table: tables[j], CollectedAt: time.Now().UTC(), Error: err.Error()}
+				}
+				stats[j] = *s
+				if onDone != nil {
+					onDone(*s)
+				}
+			}
+		}()
+	}
+	for i := range tables {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return stats
+}
+
+// StatsSortKeys are the accepted SortStats keys.
+var StatsSortKeys = []string{"size", "rows", "files", "modified", "name"}
+
+// SortStats orders stats by key, largest (or most recent) first; "name"
+// sorts alphabetically.
+func SortStats(stats []TableStats, key string) error {
+	var less func(a, b TableStats) bool
+	switch key {
+	case "size":
+		less = func(a, b TableStats) bool { return a.SizeInBytes > b.SizeInBytes }
+	case "rows":
+		less = func(a, b TableStats) bool { return a.Rows > b.Rows }
+	case "files":
+		less = func(a, b TableStats) bool { return a.NumFiles > b.NumFiles }
+	case "modified":
+		less = func(a, b TableStats) bool { return a.LastModified > b.LastModified }
+	case "name":
+		less = func(a, b TableStats) bool { return a.Table < b.Table }
+	default:
+		return fmt.Errorf("unknown sort key %q (use %s)", key, strings.Join(StatsSortKeys, ", "))
+	}
+	sort.SliceStable(stats, func(i, j int) bool { return less(stats[i], stats[j]) })
+	return nil
+}
+
+// WriteStatsCSV writes stats as CSV with a header row.
+func WriteStatsCSV(out io.Writer, stats []TableStats) error {
+	cw := csv.NewWriter(out)
+	_ = cw.Write([]string{"table", "format", "rows", "size_in_bytes", "num_files", "last_modified", "collected_at", "error"})
+	for _, s := range stats {
+		_ = cw.Write([]string{
+			s.Table,
+			s.Format,
+			strconv.FormatInt(s.Rows, 10),
+			strconv.FormatInt(s.SizeInBytes, 10),
+			strconv.FormatInt(s.NumFiles, 10),
+			s.LastModified,
+			s.CollectedAt.Format(time.RFC3339),
+			s.Error,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// StatsCache keeps collected table stats between runs.
+type StatsCache struct {
+	Tables map[string]TableStats `json:"tables"`
+
+	path string
+	mu   sync.Mutex
+}
+
+// LoadStatsCache reads the cache at path; a missing file gives an empty cache.
+func LoadStatsCache(path string) (*StatsCache, error) {
+	cache := &StatsCache{Tables: make(map[string]TableStats), path: path}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, cache); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if cache.Tables == nil {
+		cache.Tables = make(map[string]TableStats)
+	}
+	return cache, nil
+}
+
+// Get returns cached stats for a table collected within maxAge. Failed
+// collections are never returned.
+func (c *StatsCache) Get(fullName string, maxAge time.Duration) (TableStats, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.Tables[strings.ToLower(fullName)]
+	if !ok || s.Error != "" || time.Since(s.CollectedAt) > maxAge {
+		return TableStats{}, false
+	}
+	return s, true
+}
+
+// Put records stats for a table. Call Save to persist them.
+func (c *StatsCache) Put(s TableStats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Tables[strings.ToLower(s.Table)] = s
+}
+
+// Save writes the cache to disk.
+func (c *StatsCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode table stats: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", c.path, err)
+	}
+	return nil
+}
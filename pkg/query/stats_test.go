@@ -0,0 +1,70 @@
+package query
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSortStats(t *testing.T) {
+	stats := []TableStats{
+		{Table: "m.s.b", Rows: 10, SizeInBytes: 300},
+		{Table: "m.s.a", Rows: 30, SizeInBytes: 100},
+		{Table: "m.s.c", Rows: 20, SizeInBytes: 200},
+	}
+	tests := map[string]string{"size": "m.s.b", "rows": "m.s.a", "name": "m.s.a"}
+	for key, first := range tests {
+		if err := SortStats(stats, key); err != nil {
+			t.Fatal(err)
+		}
+		if stats[0].Table != first {
+			t.Errorf("sort by %s: first = %s, want %s", key, stats[0].Table, first)
+		}
+	}
+	if err := SortStats(stats, "color"); err == nil {
+		t.Error("expected error for unknown sort key")
+	}
+}
+
+func TestWriteStatsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	collected := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	err := WriteStatsCSV(&buf, []TableStats{{Table: "m.s.t", Format: "delta", Rows: 5, SizeInBytes: 2048, NumFiles: 2, CollectedAt: collected}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || lines[1] != "m.s.t,delta,5,2048,2,,2024-05-01T12:00:00Z," {
+		t.Errorf("csv = %q", buf.String())
+	}
+}
+
+func TestStatsCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	cache, err := LoadStatsCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.Put(TableStats{Table: "Main.Sales.Orders", Rows: 7, CollectedAt: time.Now()})
+	cache.Put(TableStats{Table: "main.sales.old", Rows: 1, CollectedAt: time.Now().Add(-2 * time.Hour)})
+	cache.Put(TableStats{Table: "main.sales.bad", Error: "boom", CollectedAt: time.Now()})
+	if err := cache.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadStatsCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, ok := loaded.Get("main.sales.orders", time.Hour); !ok || s.Rows != 7 {
+		t.Errorf("orders = %+v, %v", s, ok)
+	}
+	if _, ok := loaded.Get("main.sales.old", time.Hour); ok {
+		t.Error("expired entry returned")
+	}
+	if _, ok := loaded.Get("main.sales.bad", time.Hour); ok {
+		t.Error("failed entry returned")
+	}
+}
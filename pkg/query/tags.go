@@ -0,0 +1,123 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/sql"
+)
+
+// catalogTagsStatement reads every tag in a catalog from information_schema,
+// keyed by the dotted name of the tagged entity.
+const catalogTagsStatement = `SELECT catalog_name AS entity, tag_name, tag_value
+FROM %[1]s.information_schema.catalog_tags WHERE catalog_name = :catalog
+UNION ALL
+SELECT concat_ws('.', catalog_name, schema_name), tag_name, tag_value
+FROM %[1]s.information_schema.schema_tags WHERE catalog_name = :catalog
+UNION ALL
+SELECT concat_ws('.', catalog_name, schema_name, table_name), tag_name, tag_value
+FROM %[1]s.information_schema.table_tags WHERE catalog_name = :catalog
+UNION ALL
+SELECT concat_ws('.', catalog_name, schema_name, table_name, column_name), tag_name, tag_value
+FROM %[1]s.information_schema.column_tags WHERE catalog_name = :catalog`
+
+// CatalogTags returns the tags of a catalog and every schema, table and
+// column in it in one statement. Keys are lowercased dotted names such as
+// "main.sales.orders.id".
+func CatalogTags(ctx context.Context, w *databricks.WorkspaceClient, warehouseID, catalogName string) (map[string]map[string]string, error) {
+	statement := fmt.Sprintf(catalogTagsStatement, QuoteIdent(catalogName))
+	res, err := Execute(ctx, w, warehouseID, statement, sql.StatementParameterListItem{Name: "catalog", Value: catalogName})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]map[string]string)
+	for _, row := range res.Rows {
+		if len(row) < 3 {
+			continue
+		}
+		entity := strings.ToLower(row[0])
+		if tags[entity] == nil {
+			tags[entity] = make(map[string]string)
+		}
+		tags[entity][row[1]] = row[2]
+	}
+	return tags, nil
+}
+
+// tagViews maps each entity tag type to its information_schema view and the
+// columns that make up the entity name.
+var tagViews = []struct {
+	Type, View string
+	Columns    []string
+}{
+	{"catalogs", "catalog_tags", []string{"catalog_name"}},
+	{"schemas", "schema_tags", []string{"catalog_name", "schema_name"}},
+	{"tables", "table_tags", []string{"catalog_name", "schema_name", "table_name"}},
+	{"columns", "column_tags", []string{"catalog_name", "schema_name", "table_name", "column_name"}},
+	{"volumes", "volume_tags", []string{"catalog_name", "schema_name", "volume_name"}},
+}
+
+// TaggedEntity is an object that carries a tag.
+type TaggedEntity struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	TagKey   string `json:"tag_key"`
+	TagValue string `json:"tag_value,omitempty"`
+}
+
+// FindTagsStatement builds the search for objects tagged with key (and value,
+// when set). It reads system.information_schema, which covers every catalog,
+// unless catalogName narrows it to one catalog; types limits the entity types.
+func FindTagsStatement(key, value, catalogName string, types []string) (string, []sql.StatementParameterListItem) {
+	source := "system"
+	params := []sql.StatementParameterListItem{{Name: "key", Value: key}}
+	where := "tag_name = :key"
+	if value != "" {
+		where += " AND tag_value = :value"
+		params = append(params, sql.StatementParameterListItem{Name: "value", Value: value})
+	}
+	if catalogName != "" {
+		source = QuoteIdent(catalogName)
+		where += " AND catalog_name = :catalog"
+		params = append(params, sql.StatementParameterListItem{Name: "catalog", Value: catalogName})
+	}
+
+	var selects []string
+	for _, v := range tagViews {
+		if len(types) > 0 && !contains(types, v.Type) {
+			continue
+		}
+		selects = append(selects, fmt.Sprintf("SELECT '%s' AS entity_type, concat_ws('.', %s) AS entity, tag_name, tag_value\nFROM %s.information_schema.%s WHERE %s",
+			v.Type, strings.Join(v.Columns, ", "), source, v.View, where))
+	}
+	return strings.Join(selects, "\nUNION ALL\n") + "\nORDER BY entity_type, entity", params
+}
+
+// FindTags lists every object tagged with key (and value, when set).
+func FindTags(ctx context.Context, w *databricks.WorkspaceClient, warehouseID, key, value, catalogName string, types []string) ([]TaggedEntity, error) {
+	statement, params := FindTagsStatement(key, value, catalogName, types)
+	res, err := Execute(ctx, w, warehouseID, statement, params...)
+	if err != nil {
+		return nil, err
+	}
+	found := make([]TaggedEntity, 0, len(res.Rows))
+	for _, row := range res.Rows {
+		if len(row) < 4 {
+			continue
+		}
+		found = append(found, TaggedEntity{Type: row[0], Name: row[1], TagKey: row[2], TagValue: row[3]})
+	}
+	return found, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package query
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindTagsStatement(t *testing.T) {
+	stmt, params := FindTagsStatement("pii", "", "", nil)
+	if n := strings.Count(stmt, "UNION ALL"); n != 4 {
+		t.Errorf("expected 5 views, got %d UNION ALL:\n%s", n, stmt)
+	}
+	if !strings.Contains(stmt, "FROM system.information_schema.volume_tags WHERE tag_name = :key\n") {
+		t.Errorf("unexpected statement:\n%s", stmt)
+	}
+	if len(params) != 1 || params[0].Name != "key" || params[0].Value != "pii" {
+		t.Errorf("params = %+v", params)
+	}
+
+	stmt, params = FindTagsStatement("pii", "email", "main", []string{"columns"})
+	want := "SELECT 'columns' AS entity_type, concat_ws('.', catalog_name, schema_name, table_name, column_name) AS entity, tag_name, tag_value\n" +
+		"FROM `main`.information_schema.column_tags WHERE tag_name = :key AND tag_value = :value AND catalog_name = :catalog\n" +
+		"ORDER BY entity_type, entity"
+	if stmt != want {
+		t.Errorf("statement =\n%s\nwant\n%s", stmt, want)
+	}
+	if len(params) != 3 {
+		t.Errorf("params = %+v", params)
+	}
+}
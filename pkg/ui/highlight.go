@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/fatih/color"
+)
+
+var sqlKeywords = wordSet(`select from where and or not in is null as case when then else end join left
+right inner outer full cross on group by order having limit offset union all distinct with return returns
+table if exists create replace function language deterministic comment contains reads sql data modifies
+insert into values update set delete merge using matched cast true false between like ilike asc desc over
+partition window lateral view explode struct array map interval current_date current_timestamp`)
+
+var pythonKeywords = wordSet(`def return if elif else for while in not and or is none true false import from
+as class try except finally raise with lambda yield pass break continue global nonlocal assert del async await`)
+
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+// PrintCode prints a routine body with simple syntax highlighting for SQL or
+// Python: keywords, strings, numbers and comments are colored.
+func PrintCode(code, language string) {
+	keywords, comment := sqlKeywords, "--"
+	if strings.EqualFold(language, "python") {
+		keywords, comment = pythonKeywords, "#"
+	}
+
+	keyword := color.New(color.FgMagenta, color.Bold)
+	str := color.New(color.FgGreen)
+	num := color.New(color.FgCyan)
+	faint := color.New(color.Faint)
+
+	fmt.Println("--------------------------------------------------")
+	for _, line := range strings.Split(strings.TrimRight(code, "\n"), "\n") {
+		var b strings.Builder
+		runes := []rune(line)
+		for i := 0; i < len(runes); {
+			r := runes[i]
+			switch {
+			case strings.HasPrefix(string(runes[i:]), comment):
+				b.WriteString(faint.Sprint(string(runes[i:])))
+				i = len(runes)
+			case r == '\'' || r == '"':
+				j := i + 1
+				for j < len(runes) && runes[j] != r {
+					if runes[j] == '\\' {
+						j++
+					}
+					j++
+				}
+				if j >= len(runes) {
+					j = len(runes) - 1
+				}
+				b.WriteString(str.Sprint(string(runes[i : j+1])))
+				i = j + 1
+			case unicode.IsDigit(r):
+				j := i
+				for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+					j++
+				}
+				b.WriteString(num.Sprint(string(runes[i:j])))
+				i = j
+			case unicode.IsLetter(r) || r == '_':
+				j := i
+				for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+					j++
+				}
+				word := string(runes[i:j])
+				if keywords[strings.ToLower(word)] {
+					b.WriteString(keyword.Sprint(word))
+				} else {
+					b.WriteString(word)
+				}
+				i = j
+			default:
+				b.WriteRune(r)
+				i++
+			}
+		}
+		fmt.Println(b.String())
+	}
+	fmt.Println("--------------------------------------------------")
+}
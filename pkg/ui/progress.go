@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Progress reports transfer progress on a single, continuously redrawn line.
+// It implements io.Writer so it can be used with io.TeeReader or io.MultiWriter.
+type Progress struct {
+	label    string
+	total    int64
+	done     int64
+	lastDraw time.Time
+}
+
+// NewProgress creates a progress line for a transfer of total bytes, of which
+// start bytes are already done (e.g. when resuming).
+func NewProgress(label string, total, start int64) *Progress {
+	return &Progress{label: label, total: total, done: start}
+}
+
+func (p *Progress) Write(b []byte) (int, error) {
+	p.done += int64(len(b))
+	if time.Since(p.lastDraw) > 100*time.Millisecond {
+		p.draw()
+	}
+	return len(b), nil
+}
+
+// Done draws the final state and ends the line.
+func (p *Progress) Done() {
+	p.draw()
+	fmt.Fprintln(os.Stderr)
+}
+
+func (p *Progress) draw() {
+	p.lastDraw = time.Now()
+	if p.total > 0 {
+		fmt.Fprintf(os.Stderr, "\r⏳ %s  %s / %s (%d%%)   ", p.label, FormatBytes(p.done), FormatBytes(p.total), p.done*100/p.total)
+	} else {
+		fmt.Fprintf(os.Stderr, "\r⏳ %s  %s   ", p.label, FormatBytes(p.done))
+	}
+}
+
+// FormatBytes renders a byte count with a binary unit, e.g. "12.3 MiB".
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
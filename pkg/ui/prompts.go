@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/manifoldco/promptui"
@@ -26,3 +27,75 @@ func SelectPrompt(label string, items []string) (int, string, error) {
 
 	return prompt.Run()
 }
+
+// InputPrompt asks for free-form text input. An empty answer returns defaultValue.
+func InputPrompt(label, defaultValue string) (string, error) {
+	prompt := promptui.Prompt{
+		Label:   label,
+		Default: defaultValue,
+	}
+
+	result, err := prompt.Run()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(result), nil
+}
+
+// ConfirmPrompt asks a yes/no question and returns true only on an explicit yes.
+func ConfirmPrompt(label string) bool {
+	prompt := promptui.Prompt{
+		Label:     label,
+		IsConfirm: true,
+	}
+
+	_, err := prompt.Run()
+	return err == nil
+}
+
+// MultiSelectPrompt lets the user toggle several items and returns the chosen ones
+// in their original order. Selection ends with "✅ Done".
+func MultiSelectPrompt(label string, items []string) ([]string, error) {
+	return MultiSelectPromptWithSelection(label, items, nil)
+}
+
+// MultiSelectPromptWithSelection is MultiSelectPrompt with some items already
+// checked.
+func MultiSelectPromptWithSelection(label string, items, preselected []string) ([]string, error) {
+	selected := make([]bool, len(items))
+	for i, item := range items {
+		for _, p := range preselected {
+			if item == p {
+				selected[i] = true
+			}
+		}
+	}
+	for {
+		menu := make([]string, len(items)+1)
+		for i, item := range items {
+			mark := "[ ]"
+			if selected[i] {
+				mark = "[x]"
+			}
+			menu[i] = fmt.Sprintf("%s %s", mark, item)
+		}
+		menu[len(items)] = "✅ Done"
+
+		idx, choice, err := SelectPrompt(label, menu)
+		if err != nil {
+			return nil, err
+		}
+		if choice == "✅ Done" {
+			break
+		}
+		selected[idx] = !selected[idx]
+	}
+
+	var result []string
+	for i, item := range items {
+		if selected[i] {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
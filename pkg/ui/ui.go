@@ -31,7 +31,61 @@ func PrintHeader(msg string) {
 	c.Printf("\n=== %s ===\n", msg)
 }
 
+// PrintDiffLine prints a line of a diff, green for "+", red for "-" and dimmed otherwise.
+func PrintDiffLine(prefix, msg string) {
+	var c *color.Color
+	switch prefix {
+	case "+":
+		c = color.New(color.FgGreen)
+	case "-":
+		c = color.New(color.FgRed)
+	default:
+		c = color.New(color.Faint)
+	}
+	c.Printf("%s %s\n", prefix, msg)
+}
+
+// PrintUnifiedDiff prints unified diff lines with file headers in bold, hunk
+// headers in cyan, additions in green and removals in red.
+func PrintUnifiedDiff(lines []string) {
+	for _, l := range lines {
+		var c *color.Color
+		switch {
+		case strings.HasPrefix(l, "+++"), strings.HasPrefix(l, "---"):
+			c = color.New(color.Bold)
+		case strings.HasPrefix(l, "@@"):
+			c = color.New(color.FgCyan)
+		case strings.HasPrefix(l, "+"):
+			c = color.New(color.FgGreen)
+		case strings.HasPrefix(l, "-"):
+			c = color.New(color.FgRed)
+		default:
+			fmt.Println(l)
+			continue
+		}
+		c.Println(l)
+	}
+}
+
+// MaxCellWidth keeps wide values from blowing up table layouts.
+const MaxCellWidth = 60
+
+// Truncate shortens s to width runes, marking the cut with an ellipsis.
+func Truncate(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	return string(r[:width-1]) + "…"
+}
+
 func PrintTable(headers []string, rows [][]string) {
+	PrintTableWithHighlights(headers, rows, nil)
+}
+
+// PrintTableWithHighlights prints a table, rendering rows whose entry in
+// highlighted is true in yellow.
+func PrintTableWithHighlights(headers []string, rows [][]string, highlighted []bool) {
 	if len(rows) == 0 {
 		c := color.New(color.FgYellow)
 		c.Printf("⚠️  No data found.\n")
@@ -63,8 +117,13 @@ func PrintTable(headers []string, rows [][]string) {
 	printRow(sep, widths, nil)
 
 	// Print Rows
-	for _, row := range rows {
-		printRow(row, widths, nil)
+	highlightColor := color.New(color.FgYellow)
+	for i, row := range rows {
+		if i < len(highlighted) && highlighted[i] {
+			printRow(row, widths, highlightColor)
+		} else {
+			printRow(row, widths, nil)
+		}
 	}
 }
 